@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package commands
+
+import (
+	"errors"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	"github.com/spf13/cobra"
+)
+
+func NewCombineImagesCmd() *cobra.Command {
+	combineCmd := &cobra.Command{
+		Use:   "combine-images <file>[@<load-address>] ... [options]",
+		Short: "Combine images into merged HEX and BIN files",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString("output")
+			formats, _ := cmd.Flags().GetStringSlice("format")
+			report, _ := cmd.Flags().GetString("report")
+			fill, _ := cmd.Flags().GetUint8("fill")
+			if len(output) == 0 {
+				return errors.New("missing output, use --output <path>")
+			}
+			return maker.CombineImages(args, output, formats, report, fill)
+		},
+	}
+	combineCmd.Flags().StringP("output", "o", "", "Output file path without extension")
+	combineCmd.Flags().StringSlice("format", []string{"hex"}, "Output formats: hex, bin")
+	combineCmd.Flags().String("report", "", "Layout report file")
+	combineCmd.Flags().Uint8("fill", 0xFF, "Gap fill byte of binary output")
+	return combineCmd
+}
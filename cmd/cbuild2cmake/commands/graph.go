@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func NewGraphCmd() *cobra.Command {
+	graphCmd := &cobra.Command{
+		Use:   "graph <name>.cbuild-idx.yml [options]",
+		Short: "Export the solution build graph as DOT or Mermaid",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			output, _ := cmd.Flags().GetString("output")
+			useContextSet, _ := cmd.Flags().GetBool("context-set")
+
+			inputFile := args[0]
+			if !cbuildIdxRegex.MatchString(inputFile) {
+				return errors.New("invalid file argument")
+			}
+
+			m := &maker.Maker{Params: maker.Params{
+				Options:   maker.Options{UseContextSet: useContextSet},
+				InputFile: inputFile,
+				Flags:     changedFlags(cmd),
+			}}
+			content, err := m.GenerateGraph(format)
+			if err != nil {
+				return err
+			}
+			if len(output) == 0 {
+				fmt.Fprint(cmd.OutOrStdout(), content)
+				return nil
+			}
+			return utils.UpdateFile(output, content)
+		},
+	}
+	graphCmd.Flags().StringP("format", "f", "dot", "Graph format: dot, mermaid")
+	graphCmd.Flags().StringP("output", "o", "", "Output file, default is stdout")
+	graphCmd.Flags().BoolP("context-set", "S", false, "Select the context names from cbuild-set.yml")
+	return graphCmd
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+
+	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func NewLintCmd() *cobra.Command {
+	lintCmd := &cobra.Command{
+		Use:   "lint <name>.cbuild-idx.yml [options]",
+		Short: "Check the solution for problems that would only surface at build time",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			useContextSet, _ := cmd.Flags().GetBool("context-set")
+
+			inputFile := args[0]
+			if !cbuildIdxRegex.MatchString(inputFile) {
+				return errors.New("invalid file argument")
+			}
+
+			configs, _ := utils.GetInstallConfigs()
+			m := &maker.Maker{Params: maker.Params{
+				Options:        maker.Options{UseContextSet: useContextSet},
+				InputFile:      inputFile,
+				InstallConfigs: configs,
+				Flags:          changedFlags(cmd),
+			}}
+			issues, err := m.Lint()
+			if err != nil {
+				return err
+			}
+			for _, issue := range issues {
+				fmt.Fprintln(cmd.OutOrStdout(), issue.String())
+			}
+			if len(issues) > 0 {
+				return errors.New("lint found " + strconv.Itoa(len(issues)) + " issue(s)")
+			}
+			log.Info("lint found no issues")
+			return nil
+		},
+	}
+	lintCmd.Flags().BoolP("context-set", "S", false, "Select the context names from cbuild-set.yml")
+	return lintCmd
+}
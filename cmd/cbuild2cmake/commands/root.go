@@ -18,12 +18,16 @@ import (
 	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var Version string
 
 var CopyrightNotice string
 
+// cbuildIdxRegex matches the solution index file given as argument
+var cbuildIdxRegex = regexp.MustCompile(`.*\.cbuild-idx\.yml`)
+
 func printVersion(file io.Writer) {
 	fmt.Fprintf(file, "cbuild2cmake version %v%v\n", Version, CopyrightNotice)
 }
@@ -53,6 +57,16 @@ Additional help topics:{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
 Use "{{.CommandPath}} [command] --help" for more information about a command.{{end}}
 `
 
+// changedFlags returns the names of the options set on the command line,
+// they take precedence over configuration files
+func changedFlags(cmd *cobra.Command) []string {
+	var flags []string
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		flags = append(flags, flag.Name)
+	})
+	return flags
+}
+
 func preConfiguration(cmd *cobra.Command, args []string) error {
 	// configure log level
 	log.SetLevel(log.InfoLevel)
@@ -95,13 +109,31 @@ func NewRootCmd() *cobra.Command {
 			verbose, _ := cmd.Flags().GetBool("verbose")
 			useContextSet, _ := cmd.Flags().GetBool("context-set")
 			zephyr, _ := cmd.Flags().GetBool("zephyr")
+			sharedComponents, _ := cmd.Flags().GetBool("shared-components")
+			exportPackages, _ := cmd.Flags().GetBool("export-packages")
+			trustZoneImage, _ := cmd.Flags().GetBool("trustzone-image")
+			strictExecutes, _ := cmd.Flags().GetBool("strict-executes")
+			contextExecutes, _ := cmd.Flags().GetBool("context-executes")
+			relocatable, _ := cmd.Flags().GetBool("relocatable")
+			prefixMap, _ := cmd.Flags().GetBool("prefix-map")
+			incremental, _ := cmd.Flags().GetBool("incremental")
+			jobs, _ := cmd.Flags().GetInt("jobs")
 
 			options := maker.Options{
-				Quiet:         quiet,
-				Debug:         debug,
-				Verbose:       verbose,
-				UseContextSet: useContextSet,
-				Zephyr:        zephyr,
+				Quiet:            quiet,
+				Debug:            debug,
+				Verbose:          verbose,
+				UseContextSet:    useContextSet,
+				Zephyr:           zephyr,
+				SharedComponents: sharedComponents,
+				ExportPackages:   exportPackages,
+				TrustZoneImage:   trustZoneImage,
+				StrictExecutes:   strictExecutes,
+				ContextExecutes:  contextExecutes,
+				Relocatable:      relocatable,
+				PrefixMap:        prefixMap,
+				Incremental:      incremental,
+				Jobs:             jobs,
 			}
 
 			configs, _ := utils.GetInstallConfigs()
@@ -110,14 +142,18 @@ func NewRootCmd() *cobra.Command {
 				Options:        options,
 				InputFile:      inputFile,
 				InstallConfigs: configs,
+				Flags:          changedFlags(cmd),
 			}
 
-			match, _ := regexp.MatchString(".*\\.cbuild-idx.yml", inputFile)
-			if !match {
+			if !cbuildIdxRegex.MatchString(inputFile) {
 				return errors.New("invalid file argument")
 			}
 
 			log.Info("Generate CMakeLists " + Version + CopyrightNotice)
+			checkReproducible, _ := cmd.Flags().GetBool("check-reproducible")
+			if checkReproducible {
+				return maker.CheckReproducible(params)
+			}
 			m := &maker.Maker{Params: params}
 			return m.GenerateCMakeLists()
 		},
@@ -137,6 +173,21 @@ func NewRootCmd() *cobra.Command {
 	rootCmd.Flags().BoolP("verbose", "v", false, "Enable verbose messages from toolchain builds")
 	rootCmd.Flags().BoolP("context-set", "S", false, "Select the context names from cbuild-set.yml")
 	rootCmd.Flags().BoolP("zephyr", "z", false, "Generate Zephyr modules for clayer.yml files")
+	rootCmd.Flags().Bool("shared-components", false, "Build components with identical settings once and share them across contexts")
+	rootCmd.Flags().Bool("export-packages", false, "Export library contexts as CMake packages")
+	rootCmd.Flags().Bool("trustzone-image", false, "Combine hex outputs of TrustZone secure and non-secure contexts")
+	rootCmd.Flags().Bool("strict-executes", false, "Fail executes that do not update all their declared outputs")
+	rootCmd.Flags().Bool("context-executes", false, "Run executes depending on a single context in the scope of the context")
+	rootCmd.Flags().Bool("relocatable", false, "Resolve roots from environment variables or paths relative to the generated files")
+	rootCmd.Flags().Bool("prefix-map", false, "Map solution, pack and toolchain roots to placeholders in debug information and __FILE__,\n"+
+		"IAR only remaps __FILE__ and AC6 does not remap the link step, their output keeps absolute paths")
+	rootCmd.Flags().Bool("incremental", false, "Skip the build step of contexts without changes in their Ninja build tree")
+	rootCmd.Flags().IntP("jobs", "j", 0, "Number of concurrent workers, default is the number of CPUs")
+	rootCmd.Flags().Bool("check-reproducible", false, "Generate twice in memory and fail if the outputs differ, no files are written")
+
+	rootCmd.AddCommand(NewCombineImagesCmd())
+	rootCmd.AddCommand(NewGraphCmd())
+	rootCmd.AddCommand(NewLintCmd())
 
 	rootCmd.SetFlagErrorFunc(FlagErrorFunc)
 	return rootCmd
@@ -7,6 +7,8 @@
 package commands_test
 
 import (
+	"os"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -92,6 +94,57 @@ func TestCommands(t *testing.T) {
 		assert.Nil(err)
 		assert.Equal(log.DebugLevel, log.GetLevel())
 	})
+
+	t.Run("test combine images", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Nil(os.WriteFile(dir+"/image.bin", []byte{0x01, 0x02}, 0644))
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"combine-images", dir + "/image.bin@0x1000", "--output", dir + "/combined", "--format", "hex,bin"})
+		err := cmd.Execute()
+		assert.Nil(err)
+		assert.FileExists(dir + "/combined.hex")
+		assert.FileExists(dir + "/combined.bin")
+	})
+
+	t.Run("test combine images without output", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"combine-images", "image.hex"})
+		err := cmd.Execute()
+		assert.Error(err)
+	})
+
+	t.Run("test graph", func(t *testing.T) {
+		output := testRoot + "/run/solutions/executes/graph.mmd"
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"graph", testRoot + "/run/solutions/executes/solution.cbuild-idx.yml", "--format", "mermaid", "--output", output})
+		err := cmd.Execute()
+		assert.Nil(err)
+		content, err := utils.ReadFileContent(output)
+		assert.Nil(err)
+		assert.Contains(content, "flowchart LR\n  n0[\"project.Release+ARMCM0\"]")
+		assert.Contains(content, "== post-build ==>")
+	})
+
+	t.Run("test graph with invalid format", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"graph", cbuildIdxFile, "--format", "svg"})
+		err := cmd.Execute()
+		assert.Error(err)
+	})
+
+	t.Run("test lint", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"lint", cbuildIdxFile})
+		err := cmd.Execute()
+		assert.EqualError(err, "lint found 7 issue(s)")
+	})
+
+	t.Run("test lint without argument", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"lint"})
+		err := cmd.Execute()
+		assert.Error(err)
+	})
 }
 
 func TestSolutions(t *testing.T) {
@@ -269,6 +322,56 @@ set(OUTPUTS_1
 		assert.False(mismatch)
 	})
 
+	t.Run("test files with identical custom options", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		testCaseRoot := testRoot + "/run/solutions/file-options"
+		cbuildIdxFile := testCaseRoot + "/solution.cbuild-idx.yml"
+		cmd.SetArgs([]string{cbuildIdxFile, "--debug"})
+		err := cmd.Execute()
+		assert.Nil(err)
+
+		// check golden references
+		err, mismatch := inittest.CompareFiles(testCaseRoot+"/ref", testCaseRoot+"/tmp")
+		assert.Nil(err)
+		assert.False(mismatch)
+
+		// baseline with one target per file yields the same compile settings for every file
+		baseline, err := utils.ReadFileContent(testCaseRoot + "/baseline/project.GCC+ARMCM0/groups.cmake")
+		assert.Nil(err)
+		grouped, err := utils.ReadFileContent(testCaseRoot + "/tmp/project.GCC+ARMCM0/groups.cmake")
+		assert.Nil(err)
+		baselineSettings := fileCompileSettings(baseline)
+		assert.Len(baselineSettings, 7)
+		assert.Equal(baselineSettings, fileCompileSettings(grouped))
+		assert.Equal(7, strings.Count(baseline, "add_library("))
+		assert.Equal(4, strings.Count(grouped, "add_library("))
+	})
+
+	t.Run("test shared components", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		testCaseRoot := testRoot + "/run/solutions/shared-components"
+		cbuildIdxFile := testCaseRoot + "/solution.cbuild-idx.yml"
+		cmd.SetArgs([]string{cbuildIdxFile, "--shared-components", "--debug"})
+		err := cmd.Execute()
+		assert.Nil(err)
+
+		// check golden references
+		err, mismatch := inittest.CompareFiles(testCaseRoot+"/ref", testCaseRoot+"/tmp")
+		assert.Nil(err)
+		assert.False(mismatch)
+
+		// the startup component is built once by App1 and linked into App2
+		owner, err := utils.ReadFileContent(testCaseRoot + "/tmp/project.App1+ARMCM0/components.cmake")
+		assert.Nil(err)
+		consumer, err := utils.ReadFileContent(testCaseRoot + "/tmp/project.App2+ARMCM0/components.cmake")
+		assert.Nil(err)
+		assert.Contains(owner, "add_library(ARM_Device_Startup_C_Startup_2_2_0 OBJECT")
+		assert.Contains(owner, "add_library(ARM_Device_Startup_C_Startup_2_2_0_SHARED STATIC")
+		assert.NotContains(consumer, "startup_ARMCM0.c")
+		assert.Contains(consumer, "(shared, built by project.App1+ARMCM0)")
+		assert.Contains(consumer, "-Wl,--whole-archive")
+	})
+
 	t.Run("test language and scope", func(t *testing.T) {
 		cmd := commands.NewRootCmd()
 		testCaseRoot := testRoot + "/run/solutions/language-scope"
@@ -366,4 +469,36 @@ set(OUTPUTS_1
 		assert.Nil(err)
 		assert.False(mismatch)
 	})
+
+	t.Run("test check reproducible", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cbuildIdxFile := testRoot + "/run/solutions/executes/solution.cbuild-idx.yml"
+		cmd.SetArgs([]string{cbuildIdxFile, "--check-reproducible", "--strict-executes"})
+		err := cmd.Execute()
+		assert.Nil(err)
+	})
+}
+
+// fileCompileSettings maps the source files of groups.cmake to the include directories,
+// compile definitions and options of their target and to their source file properties
+func fileCompileSettings(content string) map[string]string {
+	settings := make(map[string]string)
+	libraries := regexp.MustCompile(`(?s)add_library\((\S+) OBJECT\n(.*?)\n\)`)
+	for _, library := range libraries.FindAllStringSubmatch(content, -1) {
+		var target string
+		for _, property := range []string{"include_directories", "compile_definitions", "compile_options"} {
+			block := regexp.MustCompile(`(?s)target_` + property + `\(` + regexp.QuoteMeta(library[1]) + ` PUBLIC\n(.*?)\n\)`)
+			if match := block.FindStringSubmatch(content); match != nil {
+				target += property + ":\n" + match[1] + "\n"
+			}
+		}
+		for _, file := range strings.Split(library[2], "\n") {
+			settings[strings.TrimSpace(file)] = target
+		}
+	}
+	properties := regexp.MustCompile(`(?s)set\(COMPILE_DEFINITIONS\n([^)]*)\n\)\ncbuild_set_defines\(([^)]*)\)\nset_source_files_properties\(("[^"]*")`)
+	for _, match := range properties.FindAllStringSubmatch(content, -1) {
+		settings[match[3]] += "properties:\n" + match[1] + "\n" + match[2] + "\n"
+	}
+	return settings
 }
@@ -1,5 +1,5 @@
 /*
- * Copyright (c) 2024 Arm Limited. All rights reserved.
+ * Copyright (c) 2024-2026 Arm Limited. All rights reserved.
  *
  * SPDX-License-Identifier: Apache-2.0
  */
@@ -38,6 +38,9 @@ func TestInitialization(testRoot string) {
 	compilerRoot := path.Join(absTestRoot, "run/etc")
 	os.Setenv("CMSIS_COMPILER_ROOT", compilerRoot)
 
+	// Ignore the user configuration file of the host
+	os.Setenv("CBUILD2CMAKE_USER_CONFIG", "")
+
 	// Set toolchain root
 	os.Setenv("AC6_TOOLCHAIN_6_19_0", path.Join(absTestRoot, "run/path/to/ac619/bin"))
 	os.Setenv("GCC_TOOLCHAIN_12_3_0", path.Join(absTestRoot, "run/path/to/gcc1230/bin"))
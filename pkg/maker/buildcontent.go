@@ -16,6 +16,7 @@ import (
 
 	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
 	sortedmap "github.com/gobs/sortedmap"
+	log "github.com/sirupsen/logrus"
 )
 
 type BuildFiles struct {
@@ -87,16 +88,21 @@ func GetScope(file Files) string {
 	return "PUBLIC"
 }
 
+var (
+	delimitersPattern   = regexp.MustCompile(`::|:|&|@>=|@|\.|/|\(|\)| `)
+	specialCharsPattern = regexp.MustCompile(`::|:|&|@>=|@|\.|/|\(|\)|-| `)
+	relativePathPattern = regexp.MustCompile(`\./.*|\.\./.*`)
+	executesIOPattern   = regexp.MustCompile(`(\${INPUT(_\d)?}|\${OUTPUT(_\d)?})`)
+)
+
 func ReplaceDelimiters(identifier string) string {
 	// replace component delimiters
-	pattern := regexp.MustCompile(`::|:|&|@>=|@|\.|/|\(|\)| `)
-	return pattern.ReplaceAllString(identifier, "_")
+	return delimitersPattern.ReplaceAllString(identifier, "_")
 }
 
 func ReplaceSpecialChars(identifier string) string {
 	// replace component delimiters and dash '-'
-	pattern := regexp.MustCompile(`::|:|&|@>=|@|\.|/|\(|\)|-| `)
-	return pattern.ReplaceAllString(identifier, "_")
+	return specialCharsPattern.ReplaceAllString(identifier, "_")
 }
 
 func MergeLanguageCommonIncludes(languages LanguageMap) LanguageMap {
@@ -120,45 +126,24 @@ func MergeLanguageCommonIncludes(languages LanguageMap) LanguageMap {
 }
 
 func CMakeAddLibrary(name string, buildFiles BuildFiles) string {
-	content := "\nadd_library(" + name
 	if buildFiles.Interface {
-		content += " INTERFACE)"
-	} else {
-		content += " OBJECT"
-		for _, language := range sortedmap.AsSortedMap(buildFiles.Source) {
-			for _, file := range language.Value {
-				content += "\n  \"" + file + "\""
-			}
+		return "\nadd_library(" + name + " INTERFACE)"
+	}
+	var sources []string
+	for _, language := range sortedmap.AsSortedMap(buildFiles.Source) {
+		for _, file := range language.Value {
+			sources = append(sources, CMakeQuote(file))
 		}
-		content += "\n)"
 	}
-	return content
-}
-
-func (c *Cbuild) CMakeAddLibraryCustomFile(name string, file Files) string {
-	return "\nadd_library(" + name + " OBJECT\n  \"" + c.AddRootPrefix(c.ContextRoot, file.File) + "\"\n)"
+	return CMakeCommand("add_library", []string{name, "OBJECT"}, sources...)
 }
 
-func OutputFiles(outputList []Output) (outputByProducts string, outputFile string, outputType string, customCommands string) {
-	for _, output := range outputList {
-		switch output.Type {
-		case "hex":
-			outputByProducts += "\nset(HEX_FILE \"" + output.File + "\")"
-			customCommands += "\n\n# Hex Conversion\nadd_custom_command(TARGET ${CONTEXT} POST_BUILD COMMAND ${CMAKE_OBJCOPY} ${ELF2HEX})"
-		case "bin":
-			outputByProducts += "\nset(BIN_FILE \"" + output.File + "\")"
-			customCommands += "\n\n# Bin Conversion\nadd_custom_command(TARGET ${CONTEXT} POST_BUILD COMMAND ${CMAKE_OBJCOPY} ${ELF2BIN})"
-		case "map":
-			outputByProducts += "\nset(LD_MAP_FILE \"" + output.File + "\")"
-		case "cmse-lib":
-			outputByProducts += "\nset(CMSE_LIB \"" + output.File + "\")"
-			customCommands += "\n\n# CMSE Library\nadd_custom_command(TARGET ${CONTEXT} PRE_LINK COMMAND \"\" BYPRODUCTS ${OUT_DIR}/${CMSE_LIB})"
-		case "elf", "lib":
-			outputFile = output.File
-			outputType = output.Type
-		}
+func (c *Cbuild) CMakeAddLibraryCustomFiles(name string, files []Files) string {
+	var sources []string
+	for _, file := range files {
+		sources = append(sources, CMakeQuote(c.AddRootPrefix(c.ContextRoot, file.File)))
 	}
-	return outputByProducts, outputFile, outputType, customCommands
+	return CMakeCommand("add_library", []string{name, "OBJECT"}, sources...)
 }
 
 func HasMapFile(outputList []Output) bool {
@@ -204,8 +189,10 @@ func (m *Maker) BuildDependencies() string {
 		postBuildDependencies = m.GetContextDependencies(item.Execute, item.DependsOn, postBuildDependencies)
 	}
 	// add executes statement to ${CONTEXT}-executes target of context
-	for context, dependencies := range postBuildDependencies {
-		content += m.CMakeTargetAddDependencies(context+"-executes", dependencies)
+	for _, context := range m.Contexts {
+		if dependencies, ok := postBuildDependencies[context]; ok {
+			content += m.CMakeTargetAddDependencies(context+"-executes", dependencies)
+		}
 	}
 	if len(content) > 0 {
 		content = "\n\n# Build dependencies" + content
@@ -213,21 +200,74 @@ func (m *Maker) BuildDependencies() string {
 	return content
 }
 
-func (m *Maker) GetContextDependencies(execute string, dependsOn []string, deps DependenciesMap) DependenciesMap {
-	if m.GetExecute(execute).Always == nil {
-		for _, item := range dependsOn {
-			if slices.Contains(m.Contexts, item) {
-				// collect dependency on context (post build step)
-				deps[item] = utils.AppendUniquely(deps[item], execute)
-			} else {
-				// check recursively further dependencies
-				deps = m.GetContextDependencies(execute, m.GetExecute(item).DependsOn, deps)
+// DependsOnContexts returns the indexes of the contexts the given context depends on
+func (m *Maker) DependsOnContexts(index int) []int {
+	var dependencies []int
+	context := m.Cbuilds[index].BuildDescType.Context
+	for _, cbuildRef := range m.CbuildIndex.BuildIdx.Cbuilds {
+		if cbuildRef.Project+cbuildRef.Configuration != context {
+			continue
+		}
+		for _, dependency := range cbuildRef.DependsOn {
+			for dependencyIndex, cbuild := range m.Cbuilds {
+				if cbuild.BuildDescType.Context == dependency && !slices.Contains(dependencies, dependencyIndex) {
+					dependencies = append(dependencies, dependencyIndex)
+				}
 			}
 		}
 	}
+	return dependencies
+}
+
+// DependencyContexts returns the indexes of the contexts the given context depends on,
+// including the secure counterpart of a non-secure context
+func (m *Maker) DependencyContexts(index int) []int {
+	dependencies := m.DependsOnContexts(index)
+	for _, pair := range m.TrustZonePairs {
+		if pair.NonSecure == index && !slices.Contains(dependencies, pair.Secure) {
+			dependencies = append(dependencies, pair.Secure)
+		}
+	}
+	return dependencies
+}
+
+// DependencyLibraries returns the lib and cmse-lib outputs of the contexts the given context depends on
+func (m *Maker) DependencyLibraries(index int) []string {
+	var libraries []string
+	for _, dependency := range m.DependencyContexts(index) {
+		for _, output := range m.Cbuilds[dependency].BuildDescType.Output {
+			if IsLinkableOutput(output.Type) {
+				libraries = utils.AppendUniquely(libraries, m.ContextOutputPath(dependency, output.File))
+			}
+		}
+	}
+	return libraries
+}
+
+func IsLinkableOutput(outputType string) bool {
+	return outputType == "lib" || outputType == "cmse-lib"
+}
+
+func (m *Maker) GetContextDependencies(execute string, dependsOn []string, deps DependenciesMap) DependenciesMap {
+	if m.GetExecute(execute).Always == nil {
+		m.collectContextDependencies(execute, dependsOn, deps, map[string]bool{execute: true})
+	}
 	return deps
 }
 
+func (m *Maker) collectContextDependencies(execute string, dependsOn []string, deps DependenciesMap, visited map[string]bool) {
+	for _, item := range dependsOn {
+		if slices.Contains(m.Contexts, item) {
+			// collect dependency on context (post build step)
+			deps[item] = utils.AppendUniquely(deps[item], execute)
+		} else if !visited[item] {
+			// check recursively further dependencies, each execute is visited once
+			visited[item] = true
+			m.collectContextDependencies(execute, m.GetExecute(item).DependsOn, deps, visited)
+		}
+	}
+}
+
 func (m *Maker) GetExecute(execute string) Executes {
 	for _, item := range m.CbuildIndex.BuildIdx.Executes {
 		if item.Execute == execute {
@@ -268,14 +308,14 @@ func CMakeTargetIncludeDirectories(name string, includes ScopeMap) string {
 					if strings.Contains(file, "$<TARGET_PROPERTY:") {
 						content += fileIndentation + file
 					} else {
-						allLanguagesContent += fileIndentation + "\"" + file + "\""
+						allLanguagesContent += fileIndentation + CMakeQuote(file)
 					}
 				}
 			} else {
 				if len(language.Value) > 0 {
 					specificLanguageContent += fileIndentation + "$<$<COMPILE_LANGUAGE:" + language.Key + ">:"
 					for _, file := range language.Value {
-						specificLanguageContent += fileIndentation + "  \"" + file + "\""
+						specificLanguageContent += fileIndentation + "  " + CMakeQuote(file)
 					}
 					specificLanguageContent += fileIndentation + ">"
 				}
@@ -296,7 +336,7 @@ func CMakeTargetCompileDefinitions(name string, parent string, scope string, def
 	}
 	if len(parent) > 0 {
 		if len(undefine) > 0 {
-			content += "\n  $<LIST:FILTER,$<TARGET_PROPERTY:" + parent + ",INTERFACE_COMPILE_DEFINITIONS>,EXCLUDE,^" + strings.Join(undefine, ".*,^") + ".*>"
+			content += "\n  $<LIST:FILTER,$<TARGET_PROPERTY:" + parent + ",INTERFACE_COMPILE_DEFINITIONS>,EXCLUDE," + UndefinePattern(undefine) + ">"
 		} else {
 			content += "\n  $<TARGET_PROPERTY:" + parent + ",INTERFACE_COMPILE_DEFINITIONS>"
 		}
@@ -309,17 +349,36 @@ func ListIncludeDirectories(includes []string, delimiter string) string {
 	return strings.Join(includes, delimiter)
 }
 
-func ListCompileDefinitions(defines []interface{}, delimiter string) string {
+// UndefinePattern returns the regular expression matching the definitions of the given macros by exact name
+func UndefinePattern(undefine []string) string {
+	var patterns []string
+	for _, name := range undefine {
+		name = regexp.QuoteMeta(name)
+		patterns = append(patterns, "^"+name+"$", "^"+name+"=")
+	}
+	return strings.Join(patterns, "|")
+}
+
+// CompileDefinitions returns the definitions as NAME or NAME=VALUE elements, values are escaped
+// to be used inside generator expressions, an explicitly empty value is kept as NAME=
+func CompileDefinitions(defines []interface{}) []string {
 	var definesList []string
 	for _, define := range defines {
-		key, value := utils.GetDefine(define)
-		pair := key
-		if len(value) > 0 {
-			value = strings.ReplaceAll(value, ">", "$<ANGLE-R>")
-			pair += "=" + value
+		def, _ := utils.ParseDefine(define)
+		pair := CMakeGenexEscape(def.Name)
+		if def.HasValue {
+			pair += "=" + CMakeGenexEscape(def.Value)
 		}
 		definesList = append(definesList, pair)
 	}
+	return definesList
+}
+
+func ListCompileDefinitions(defines []interface{}, delimiter string) string {
+	var definesList []string
+	for _, define := range CompileDefinitions(defines) {
+		definesList = append(definesList, CMakeArgument(define))
+	}
 	return strings.Join(definesList, delimiter)
 }
 
@@ -335,6 +394,83 @@ func (c *Cbuild) ListGroupsAndComponents() []string {
 
 func (c *Cbuild) CMakeTargetCompileOptionsGlobal(name string, scope string) string {
 	// options from context settings
+	optionsMap := c.ProcessorCompileOptionsMap()
+
+	// add global misc options
+	c.GetCompileOptionsLanguageMap((c.BuildDescType.Lto), c.BuildDescType.Misc, &optionsMap)
+
+	// pre-includes global
+	for _, preInclude := range c.PreIncludeGlobal {
+		optionsMap["C,CXX"] = append(optionsMap["C,CXX"], "${_PI}\""+preInclude+"\"")
+	}
+
+	// path prefix maps
+	if c.PrefixMap {
+		for _, language := range c.Languages {
+			optionsMap[language] = append(optionsMap[language], c.PrefixMapOptions(language)...)
+		}
+	}
+
+	// target compile options
+	content := "\ntarget_compile_options(" + name + " " + scope
+	for _, language := range sortedmap.AsSortedMap(optionsMap) {
+		content += c.LanguageSpecificCompileOptions(language.Key, language.Value...)
+	}
+	content += "\n)"
+	return content
+}
+
+// Roots replaced by stable placeholders in debug information and __FILE__ strings
+var PrefixMapRoots = []string{"SOLUTION_ROOT", "CMSIS_PACK_ROOT", "REGISTERED_TOOLCHAIN_ROOT"}
+
+// PrefixMapOptions returns the toolchain options remapping the roots for the given language
+func (c *Cbuild) PrefixMapOptions(language string) []string {
+	if language != "C" && language != "CXX" && language != "ASM" {
+		return nil
+	}
+	var flags []string
+	switch c.Toolchain {
+	case "GCC", "CLANG":
+		flags = []string{"-ffile-prefix-map="}
+	case "AC6":
+		flags = []string{"-fdebug-prefix-map=", "-fmacro-prefix-map="}
+	case "IAR":
+		// the IAR compiler has no prefix map, paths are removed from __FILE__ instead
+		if language == "ASM" {
+			return nil
+		}
+		return []string{"--no_path_in_file_macros"}
+	}
+	var options []string
+	for _, flag := range flags {
+		for _, root := range PrefixMapRoots {
+			options = append(options, flag+"\"${"+root+"}\"=/"+root)
+		}
+	}
+	return options
+}
+
+// Limits of the prefix map by toolchain, their output still depends on the build paths
+var PrefixMapLimits = map[string]string{
+	"AC6": "the link step is not remapped",
+	"IAR": "only __FILE__ is remapped, debug information keeps absolute paths",
+}
+
+// CheckPrefixMap warns once per toolchain whose output is not path-independent with prefix map
+func (m *Maker) CheckPrefixMap() {
+	var toolchains []string
+	for index := range m.Cbuilds {
+		toolchain := m.RegisteredToolchains[m.SelectedToolchainVersion[index]].Name
+		if _, ok := PrefixMapLimits[toolchain]; ok {
+			toolchains = utils.AppendUniquely(toolchains, toolchain)
+		}
+	}
+	for _, toolchain := range toolchains {
+		log.Warn("prefix map does not make " + toolchain + " output path-independent: " + PrefixMapLimits[toolchain])
+	}
+}
+
+func (c *Cbuild) ProcessorCompileOptionsMap() map[string][]string {
 	optionsMap := make(map[string][]string)
 	for _, language := range c.Languages {
 		prefix := language
@@ -353,21 +489,7 @@ func (c *Cbuild) CMakeTargetCompileOptionsGlobal(name string, scope string) stri
 			optionsMap[language] = append(optionsMap[language], "${"+prefix+"_BYTE_ORDER}")
 		}
 	}
-	// add global misc options
-	c.GetCompileOptionsLanguageMap((c.BuildDescType.Lto), c.BuildDescType.Misc, &optionsMap)
-
-	// pre-includes global
-	for _, preInclude := range c.PreIncludeGlobal {
-		optionsMap["C,CXX"] = append(optionsMap["C,CXX"], "${_PI}\""+preInclude+"\"")
-	}
-
-	// target compile options
-	content := "\ntarget_compile_options(" + name + " " + scope
-	for _, language := range sortedmap.AsSortedMap(optionsMap) {
-		content += c.LanguageSpecificCompileOptions(language.Key, language.Value...)
-	}
-	content += "\n)"
-	return content
+	return optionsMap
 }
 
 func (c *Cbuild) CMakeTargetLinkLibrariesGlobal() string {
@@ -377,6 +499,15 @@ func (c *Cbuild) CMakeTargetLinkLibrariesGlobal() string {
 		content += "\n  " + library
 	}
 
+	// set content
+	for _, library := range c.LinkLibrariesGlobal() {
+		content += "\n  " + quoteEntry(library)
+	}
+	content += "\n)"
+	return content
+}
+
+func (c *Cbuild) LinkLibrariesGlobal() []string {
 	// format whole-archive library entries (GCC and CLANG)
 	libraries := c.FormatWholeArchive(c.WholeArchiveGlobal)
 
@@ -385,14 +516,7 @@ func (c *Cbuild) CMakeTargetLinkLibrariesGlobal() string {
 	libraries = append(libraries, c.LibraryGlobal...)
 
 	// rescan libraries: special handling for GCC
-	libraries = c.RescanLibs(libraries)
-
-	// set content
-	for _, library := range libraries {
-		content += "\n  " + quoteEntry(library)
-	}
-	content += "\n)"
-	return content
+	return c.RescanLibs(libraries)
 }
 
 func (c *Cbuild) CMakeTargetLinkLibraries(name string, scope string, libraries ...string) string {
@@ -409,14 +533,14 @@ func quoteEntry(entry string) string {
 	if len(trimmed) == 0 {
 		return entry
 	}
-	if !strings.Contains(trimmed, " ") {
+	if !strings.ContainsAny(trimmed, " \t()#\"\\;") {
 		return entry
 	}
 	leadingSpaces := entry[:len(entry)-len(trimmed)]
-	if strings.HasPrefix(trimmed, "\"") && strings.HasSuffix(trimmed, "\"") {
+	if len(trimmed) > 1 && strings.HasPrefix(trimmed, "\"") && strings.HasSuffix(trimmed, "\"") {
 		return entry
 	}
-	return leadingSpaces + "\"" + trimmed + "\""
+	return leadingSpaces + CMakeQuote(trimmed)
 }
 
 func (c *Cbuild) CMakeTargetCompileOptions(name string, scope string, lto bool, misc Misc, preIncludes []string, parent string) string {
@@ -540,11 +664,15 @@ func (c *Cbuild) LanguageSpecificCompileOptions(language string, options ...stri
 }
 
 func AddShellPrefix(input string) string {
-	return "\"SHELL:" + strings.ReplaceAll(input, "\"", "\\\"") + "\""
+	return CMakeQuote("SHELL:" + input)
 }
 
 func (c *Cbuild) AddRootPrefix(base string, input string) string {
-	return AddRootPrefix(base, input, c.SolutionRoot)
+	return RelocatePath(AddRootPrefix(base, input, c.SolutionRoot), c.Roots)
+}
+
+func (m *Maker) AddRootPrefix(base string, input string) string {
+	return RelocatePath(AddRootPrefix(base, input, m.SolutionRoot), m.Roots)
 }
 
 func AddRootPrefix(base string, input string, solutionRoot string) string {
@@ -580,6 +708,7 @@ func (c *Cbuild) ClassifyFiles(files []Files) BuildFiles {
 		}
 	}
 
+	includes := make(map[[2]string]*utils.OrderedSet)
 	for _, file := range files {
 		if file.Attr == "template" {
 			continue
@@ -597,13 +726,15 @@ func (c *Cbuild) ClassifyFiles(files []Files) BuildFiles {
 			if strings.Contains(file.Category, "header") {
 				includePath = path.Dir(includePath)
 			}
-			if _, ok := buildFiles.Include[scope]; !ok {
-				buildFiles.Include[scope] = make(LanguageMap)
+			set, ok := includes[[2]string{scope, language}]
+			if !ok {
+				set = utils.NewOrderedSet()
+				includes[[2]string{scope, language}] = set
 			}
 			if file.Attr == "config" {
-				buildFiles.Include[scope][language] = utils.PrependUniquely(buildFiles.Include[scope][language], c.AddRootPrefix(c.ContextRoot, includePath))
+				set.Prepend(c.AddRootPrefix(c.ContextRoot, includePath))
 			} else {
-				buildFiles.Include[scope][language] = utils.AppendUniquely(buildFiles.Include[scope][language], c.AddRootPrefix(c.ContextRoot, includePath))
+				set.Append(c.AddRootPrefix(c.ContextRoot, includePath))
 			}
 		case "source", "sourceAsm", "sourceC", "sourceCpp":
 			language := GetLanguage(file)
@@ -627,6 +758,12 @@ func (c *Cbuild) ClassifyFiles(files []Files) BuildFiles {
 			c.PreIncludeGlobal = append(c.PreIncludeGlobal, c.AddRootPrefix(c.ContextRoot, file.File))
 		}
 	}
+	for key, set := range includes {
+		if _, ok := buildFiles.Include[key[0]]; !ok {
+			buildFiles.Include[key[0]] = make(LanguageMap)
+		}
+		buildFiles.Include[key[0]][key[1]] = set.Elements()
+	}
 
 	return buildFiles
 }
@@ -653,13 +790,13 @@ func (c *Cbuild) MergeIncludes(includes ScopeMap, scope string, parent string, a
 }
 
 func AppendGlobalIncludes(includes LanguageMap, elements ScopeMap) LanguageMap {
-	for scope, languages := range elements {
-		if scope != "PRIVATE" {
+	for _, scope := range sortedmap.AsSortedMap(elements) {
+		if scope.Key != "PRIVATE" {
 			if includes == nil {
 				includes = make(LanguageMap)
 			}
-			for language, paths := range languages {
-				includes[language] = utils.AppendUniquely(includes[language], paths...)
+			for _, language := range sortedmap.AsSortedMap(scope.Value) {
+				includes[language.Key] = utils.AppendUniquely(includes[language.Key], language.Value...)
 			}
 		}
 	}
@@ -793,6 +930,34 @@ func HasFileCustomOptions(file Files) bool {
 	return false
 }
 
+// CustomOptionsKey identifies the file options that are applied through a dedicated target,
+// files sharing the same key get identical compile commands and can share the target
+func CustomOptionsKey(file Files) string {
+	key := []string{
+		strings.Join(file.AddPath, ";"),
+		strings.Join(file.AddPathAsm, ";"),
+		strings.Join(file.DelPath, ";"),
+	}
+	if GetLanguage(file) == "ASM" {
+		key = append(key, "ASM")
+	} else {
+		key = append(key, ListCompileDefinitions(file.Define, ";"), strings.Join(file.Undefine, ";"))
+	}
+	return strings.Join(key, "\n")
+}
+
+// GroupCustomFiles collects source files with custom options sharing the same options key
+func GroupCustomFiles(files []Files) map[string][]Files {
+	groups := make(map[string][]Files)
+	for _, file := range files {
+		if strings.Contains(file.Category, "source") && HasFileCustomOptions(file) {
+			key := CustomOptionsKey(file)
+			groups[key] = append(groups[key], file)
+		}
+	}
+	return groups
+}
+
 func (c *Cbuild) CompilerAbstractions(abstractions CompilerAbstractions, language string) string {
 	languageStandard := map[string]string{
 		"C":   abstractions.LanguageC,
@@ -838,7 +1003,7 @@ func (c *Cbuild) CMakeSetFileProperties(file Files, abstractions CompilerAbstrac
 	isGenerated := slices.Contains(c.GeneratedFiles, filename)
 	// set file properties
 	if hasMisc || file.Lto || hasAbstractions || isGenerated {
-		content += "\nset_source_files_properties(\"" + filename + "\" PROPERTIES"
+		content += "\nset_source_files_properties(" + CMakeQuote(filename) + " PROPERTIES"
 		if hasMisc || file.Lto || hasAbstractions {
 			content += "\n  COMPILE_OPTIONS \"" + GetFileOptions(file, hasAbstractions, ";") + "\""
 		}
@@ -862,11 +1027,11 @@ func (c *Cbuild) SetFileAsmDefines(file Files, parentMiscAsm []string) string {
 			}
 			content += "\nset(COMPILE_DEFINITIONS\n  " + ListCompileDefinitions(file.DefineAsm, "\n  ") + "\n)"
 			content += "\ncbuild_set_defines(" + syntax + " COMPILE_DEFINITIONS)"
-			content += "\nset_source_files_properties(\"" + c.AddRootPrefix(c.ContextRoot, file.File) +
-				"\" PROPERTIES\n  COMPILE_FLAGS \"${COMPILE_DEFINITIONS}\"\n)"
+			content += "\nset_source_files_properties(" + CMakeQuote(c.AddRootPrefix(c.ContextRoot, file.File)) +
+				" PROPERTIES\n  COMPILE_FLAGS \"${COMPILE_DEFINITIONS}\"\n)"
 		} else {
-			content += "\nset_source_files_properties(\"" + c.AddRootPrefix(c.ContextRoot, file.File) +
-				"\" PROPERTIES\n  COMPILE_DEFINITIONS \"" + ListCompileDefinitions(file.DefineAsm, ";") + "\"\n)"
+			content += "\nset_source_files_properties(" + CMakeQuote(c.AddRootPrefix(c.ContextRoot, file.File)) +
+				" PROPERTIES\n  COMPILE_DEFINITIONS " + CMakeLiteral(strings.Join(CompileDefinitions(file.DefineAsm), ";")) + "\n)"
 		}
 	}
 	return content
@@ -885,10 +1050,10 @@ func (c *Cbuild) AddContextLanguage(language string) {
 }
 
 func (c *Cbuild) LinkerOptions() (linkerVars string, linkerOptions string) {
-	linkerVars += "\nset(LD_SCRIPT \"" + c.AddRootPrefix(c.ContextRoot, c.BuildDescType.Linker.Script) + "\")"
+	linkerVars += "\nset(LD_SCRIPT " + CMakeQuote(c.AddRootPrefix(c.ContextRoot, c.BuildDescType.Linker.Script)) + ")"
 	linkerDeps := "\nset(LD_DEPS ${LD_SCRIPT}"
 	if len(c.BuildDescType.Linker.Regions) > 0 {
-		linkerVars += "\nset(LD_REGIONS \"" + c.AddRootPrefix(c.ContextRoot, c.BuildDescType.Linker.Regions) + "\")"
+		linkerVars += "\nset(LD_REGIONS " + CMakeQuote(c.AddRootPrefix(c.ContextRoot, c.BuildDescType.Linker.Regions)) + ")"
 		linkerDeps += " ${LD_REGIONS}"
 	}
 	linkerDeps += ")"
@@ -909,6 +1074,12 @@ func (c *Cbuild) LinkerOptions() (linkerVars string, linkerOptions string) {
 	if c.LinkerLto || c.BuildDescType.Lto {
 		linkerOptions += "\n  " + AddShellPrefix("${LD_LTO}")
 	}
+	if c.PrefixMap && (c.Toolchain == "GCC" || c.Toolchain == "CLANG") {
+		// link time code generation emits debug information as well
+		for _, option := range c.PrefixMapOptions("C") {
+			linkerOptions += "\n  " + AddShellPrefix(option)
+		}
+	}
 	options := c.BuildDescType.Misc.Link
 	for _, language := range c.Languages {
 		if language == "C" {
@@ -925,7 +1096,7 @@ func (c *Cbuild) LinkerOptions() (linkerVars string, linkerOptions string) {
 	linkerOptions += linkerDeps + "\nset_target_properties(${CONTEXT} PROPERTIES LINK_DEPENDS \"${LD_DEPS}\")"
 	if path.Ext(c.BuildDescType.Linker.Script) == ".src" || len(c.BuildDescType.Linker.Regions) > 0 || len(c.BuildDescType.Linker.Define) > 0 {
 		linkerScriptPP := strings.TrimSuffix(path.Base(c.BuildDescType.Linker.Script), ".src")
-		linkerVars += "\nset(LD_SCRIPT_PP \"${CMAKE_CURRENT_BINARY_DIR}/" + linkerScriptPP + "\")"
+		linkerVars += "\nset(LD_SCRIPT_PP " + CMakeQuote("${CMAKE_CURRENT_BINARY_DIR}/"+linkerScriptPP) + ")"
 		linkerOptions += "\n\n# Linker script pre-processing\nadd_custom_command(TARGET ${CONTEXT} PRE_LINK COMMAND ${CPP} ARGS ${CPP_ARGS_LD_SCRIPT} BYPRODUCTS ${LD_SCRIPT_PP})"
 	} else {
 		linkerVars += "\nset(LD_SCRIPT_PP ${LD_SCRIPT})"
@@ -935,9 +1106,8 @@ func (c *Cbuild) LinkerOptions() (linkerVars string, linkerOptions string) {
 
 func (c *Cbuild) AdjustRelativePath(option string) string {
 	if !strings.Contains(option, "${SOLUTION_ROOT}") {
-		pattern := regexp.MustCompile(`\./.*|\.\./.*`)
-		if pattern.MatchString(option) {
-			relativePath := pattern.FindString(option)
+		if relativePathPattern.MatchString(option) {
+			relativePath := relativePathPattern.FindString(option)
 			option = strings.Replace(option, relativePath, c.AddRootPrefix(c.ContextRoot, relativePath), 1)
 		}
 	}
@@ -945,15 +1115,14 @@ func (c *Cbuild) AdjustRelativePath(option string) string {
 }
 
 func QuoteArguments(cmd string) string {
-	pattern := regexp.MustCompile(`(\${INPUT(_\d)?}|\${OUTPUT(_\d)?})`)
-	return pattern.ReplaceAllString(cmd, "\"${1}\"")
+	return executesIOPattern.ReplaceAllString(cmd, "\"${1}\"")
 }
 
 func (m *Maker) ListExecutesIOs(io string, list []string, run string) string {
 	content := "\nset(" + io
 	var listItems string
 	for index, input := range list {
-		content += "\n  " + AddRootPrefix(m.CbuildIndex.RelDir, input, m.SolutionRoot)
+		content += "\n  " + m.AddRootPrefix(m.CbuildIndex.RelDir, input)
 		if strings.Contains(run, "${"+io+"_"+strconv.Itoa(index)+"}") {
 			listItems += "\nlist(GET " + io + " " + strconv.Itoa(index) + " " + io + "_" + strconv.Itoa(index) + ")"
 		}
@@ -965,7 +1134,7 @@ func (m *Maker) ListExecutesIOs(io string, list []string, run string) string {
 
 func (m *Maker) GetGeneratedFiles(list []string) {
 	for _, input := range list {
-		file := AddRootPrefix(m.CbuildIndex.RelDir, input, m.SolutionRoot)
+		file := m.AddRootPrefix(m.CbuildIndex.RelDir, input)
 		m.GeneratedFiles = utils.AppendUniquely(m.GeneratedFiles, file)
 	}
 }
@@ -976,13 +1145,17 @@ func (m *Maker) ExecutesCommands(executes []Executes) string {
 		content += "\n\n# Execute: " + item.Execute
 		customTarget := "\nadd_custom_target(" + item.Execute + " ALL"
 		runAlways := item.Always != nil
+		checkOutputs := m.CheckOutputsCommand(item)
 		if runAlways {
-			customTarget += "\n  COMMAND ${CMAKE_COMMAND} -E cmake_echo_color --green \"Executing: " + item.Execute + "\""
-			customTarget += "\n  COMMAND " + QuoteArguments(item.Run)
+			customTarget += "\n  COMMAND ${CMAKE_COMMAND} -E cmake_echo_color --green " + CMakeLiteral("Executing: "+item.Execute)
+			customTarget += m.ExecuteRunCommand(item) + checkOutputs
 			if len(item.Output) > 0 {
 				customTarget += "\n  BYPRODUCTS ${OUTPUT}"
 			}
-			customTarget += "\n  USES_TERMINAL\n)"
+			customTarget += m.ExecuteWorkingDirectory(item) + "\n  USES_TERMINAL\n)"
+			if len(item.Depfile) > 0 {
+				log.Warn("execute '" + item.Execute + "': depfile is ignored for executes running always")
+			}
 		} else {
 			customTarget += " DEPENDS ${OUTPUT})"
 		}
@@ -991,23 +1164,33 @@ func (m *Maker) ExecutesCommands(executes []Executes) string {
 			content += m.ListExecutesIOs("INPUT", item.Input, item.Run)
 			customCommand += " DEPENDS ${INPUT}"
 		}
+		if m.IsContextExecute(item.Execute) {
+			if len(item.Input) > 0 {
+				customCommand += " ${CONTEXT}"
+			} else {
+				customCommand += " DEPENDS ${CONTEXT}"
+			}
+		}
 		executeCommandNameAdded := false
 		if !runAlways && len(item.Output) == 0 {
 			item.Output = append(item.Output, "${CMAKE_CURRENT_BINARY_DIR}/"+item.Execute+".stamp")
-			customCommand += "\n  COMMAND ${CMAKE_COMMAND} -E cmake_echo_color --green \"Executing: " + item.Execute + "\""
-			customCommand += "\n  COMMAND ${CMAKE_COMMAND} -E touch \"" + item.Execute + ".stamp\""
+			customCommand += "\n  COMMAND ${CMAKE_COMMAND} -E cmake_echo_color --green " + CMakeLiteral("Executing: "+item.Execute)
+			stamp := item.Execute + ".stamp"
+			if len(item.WorkingDir) > 0 {
+				stamp = "${CMAKE_CURRENT_BINARY_DIR}/" + stamp
+			}
+			customCommand += "\n  COMMAND ${CMAKE_COMMAND} -E touch " + CMakeQuote(stamp)
 			executeCommandNameAdded = true
 		}
 		if len(item.Output) > 0 {
 			content += m.ListExecutesIOs("OUTPUT", item.Output, item.Run)
-			m.GetGeneratedFiles(item.Output)
 		}
 		content += customTarget
 		if !runAlways {
 			if !executeCommandNameAdded {
-				customCommand += "\n  COMMAND ${CMAKE_COMMAND} -E cmake_echo_color --green \"Executing: " + item.Execute + "\""
+				customCommand += "\n  COMMAND ${CMAKE_COMMAND} -E cmake_echo_color --green " + CMakeLiteral("Executing: "+item.Execute)
 			}
-			customCommand += "\n  COMMAND " + QuoteArguments(item.Run) + "\n  USES_TERMINAL\n)"
+			customCommand += m.ExecuteRunCommand(item) + checkOutputs + m.ExecuteDepfile(item) + m.ExecuteWorkingDirectory(item) + "\n  USES_TERMINAL\n)"
 			content += customCommand
 		}
 	}
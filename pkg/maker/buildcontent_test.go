@@ -7,10 +7,16 @@
 package maker_test
 
 import (
+	"bytes"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,6 +48,15 @@ func TestReplaceSpecialChars(t *testing.T) {
 func TestBuildContent(t *testing.T) {
 	assert := assert.New(t)
 
+	t.Run("test undefine pattern", func(t *testing.T) {
+		pattern := regexp.MustCompile(maker.UndefinePattern([]string{"DEBUG", "LEVEL"}))
+		assert.Equal("^DEBUG$|^DEBUG=|^LEVEL$|^LEVEL=", pattern.String())
+		assert.True(pattern.MatchString("DEBUG"))
+		assert.True(pattern.MatchString("LEVEL=2"))
+		assert.False(pattern.MatchString("DEBUG_LEVEL"))
+		assert.False(pattern.MatchString("NDEBUG"))
+	})
+
 	t.Run("test get file language", func(t *testing.T) {
 		var file maker.Files
 		assert.Empty(file.Language)
@@ -176,6 +191,56 @@ func TestBuildContent(t *testing.T) {
 		assert.Contains(content, "\"SHELL:${_PI}\\\"${SOLUTION_ROOT}/project/RTE/class/pre-include.h\\\"\"")
 	})
 
+	t.Run("test prefix map options", func(t *testing.T) {
+		var cbuild maker.Cbuild
+		cbuild.Toolchain = "GCC"
+		assert.Equal([]string{
+			"-ffile-prefix-map=\"${SOLUTION_ROOT}\"=/SOLUTION_ROOT",
+			"-ffile-prefix-map=\"${CMSIS_PACK_ROOT}\"=/CMSIS_PACK_ROOT",
+			"-ffile-prefix-map=\"${REGISTERED_TOOLCHAIN_ROOT}\"=/REGISTERED_TOOLCHAIN_ROOT",
+		}, cbuild.PrefixMapOptions("C"))
+		assert.Nil(cbuild.PrefixMapOptions("LD"))
+		cbuild.Toolchain = "AC6"
+		options := cbuild.PrefixMapOptions("CXX")
+		assert.Len(options, 6)
+		assert.Contains(options, "-fdebug-prefix-map=\"${SOLUTION_ROOT}\"=/SOLUTION_ROOT")
+		assert.Contains(options, "-fmacro-prefix-map=\"${CMSIS_PACK_ROOT}\"=/CMSIS_PACK_ROOT")
+		cbuild.Toolchain = "IAR"
+		assert.Equal([]string{"--no_path_in_file_macros"}, cbuild.PrefixMapOptions("C"))
+		assert.Nil(cbuild.PrefixMapOptions("ASM"))
+	})
+
+	t.Run("test prefix map limits", func(t *testing.T) {
+		var buffer bytes.Buffer
+		output := log.StandardLogger().Out
+		log.SetOutput(&buffer)
+		defer log.SetOutput(output)
+
+		var m maker.Maker
+		gcc, _ := semver.NewVersion("13.2.1")
+		iar, _ := semver.NewVersion("9.50.1")
+		m.RegisteredToolchains = map[*semver.Version]maker.Toolchain{gcc: {Name: "GCC"}, iar: {Name: "IAR"}}
+		m.SelectedToolchainVersion = []*semver.Version{gcc, iar, iar}
+		m.Cbuilds = make([]maker.Cbuild, 3)
+		m.CheckPrefixMap()
+		assert.Equal(1, strings.Count(buffer.String(), "prefix map does not make IAR output path-independent: only __FILE__ is remapped"))
+		assert.NotContains(buffer.String(), "GCC")
+	})
+
+	t.Run("test cmake target compile options global with prefix map", func(t *testing.T) {
+		var cbuild maker.Cbuild
+		cbuild.Toolchain = "CLANG"
+		cbuild.Languages = []string{"ASM", "C"}
+		content := cbuild.CMakeTargetCompileOptionsGlobal("${CONTEXT}", "PUBLIC")
+		assert.NotContains(content, "prefix-map")
+		cbuild.PrefixMap = true
+		content = cbuild.CMakeTargetCompileOptionsGlobal("${CONTEXT}", "PUBLIC")
+		assert.Contains(content, "$<$<COMPILE_LANGUAGE:ASM>:\n    \"SHELL:${ASM_CPU}\"\n    \"SHELL:${ASM_FLAGS}\"\n    \"SHELL:-ffile-prefix-map=\\\"${SOLUTION_ROOT}\\\"=/SOLUTION_ROOT\"")
+		assert.Equal(2, strings.Count(content, "=/REGISTERED_TOOLCHAIN_ROOT"))
+		_, linkerOptions := cbuild.LinkerOptions()
+		assert.Contains(linkerOptions, "\"SHELL:-ffile-prefix-map=\\\"${CMSIS_PACK_ROOT}\\\"=/CMSIS_PACK_ROOT\"")
+	})
+
 	t.Run("test language specific compile options", func(t *testing.T) {
 		var misc = maker.Misc{
 			ASM: []string{"-asm-flag"},
@@ -245,7 +310,7 @@ func TestBuildContent(t *testing.T) {
 				Type: "map",
 			},
 		}
-		outputByProducts, outputFile, outputType, customCommands := maker.OutputFiles(output)
+		outputByProducts, outputFile, outputType, customCommands := maker.OutputFiles(output, "AC6", maker.DefaultOutputConverters())
 		assert.Equal(outputFile, "./arfifact.elf")
 		assert.Equal(outputType, "elf")
 		assert.Contains(outputByProducts, "binary.bin")
@@ -263,7 +328,7 @@ func TestBuildContent(t *testing.T) {
 				Type: "lib",
 			},
 		}
-		_, outputFile, outputType, _ := maker.OutputFiles(output)
+		_, outputFile, outputType, _ := maker.OutputFiles(output, "AC6", maker.DefaultOutputConverters())
 		assert.Equal(outputFile, "./library.a")
 		assert.Equal(outputType, "lib")
 	})
@@ -430,6 +495,47 @@ add_dependencies(project.debug+target-executes
 )`)
 	})
 
+	t.Run("test context dependencies with cycle", func(t *testing.T) {
+		var m maker.Maker
+		m.Vars.Contexts = []string{"project.debug+target"}
+		m.CbuildIndex.BuildIdx.Executes = []maker.Executes{
+			{
+				Execute:   "Loop_A",
+				DependsOn: []string{"Loop_B", "project.debug+target"},
+			},
+			{
+				Execute:   "Loop_B",
+				DependsOn: []string{"Loop_A"},
+			},
+		}
+		deps := m.GetContextDependencies("Loop_A", []string{"Loop_B", "project.debug+target"}, make(maker.DependenciesMap))
+		assert.Equal(maker.DependenciesMap{"project.debug+target": {"Loop_A"}}, deps)
+		deps = m.GetContextDependencies("Loop_B", []string{"Loop_A"}, deps)
+		assert.Equal(maker.DependenciesMap{"project.debug+target": {"Loop_A", "Loop_B"}}, deps)
+	})
+
+	t.Run("test dependency libraries", func(t *testing.T) {
+		var m maker.Maker
+		m.SolutionRoot = "/solution"
+		m.CbuildIndex.BuildIdx.Cbuilds = []maker.Cbuilds{
+			{Project: "app", Configuration: ".debug+target", DependsOn: []string{"lib.debug+target", "secure.debug+target", "Generate"}},
+			{Project: "lib", Configuration: ".debug+target"},
+			{Project: "secure", Configuration: ".debug+target"},
+		}
+		m.Cbuilds = make([]maker.Cbuild, 3)
+		m.Cbuilds[0].BuildDescType.Context = "app.debug+target"
+		m.Cbuilds[1].BuildDescType.Context = "lib.debug+target"
+		m.Cbuilds[1].BaseDir = "/solution/lib"
+		m.Cbuilds[1].BuildDescType.OutputDirs.Outdir = "../out/lib"
+		m.Cbuilds[1].BuildDescType.Output = []maker.Output{{Type: "lib", File: "lib.a"}}
+		m.Cbuilds[2].BuildDescType.Context = "secure.debug+target"
+		m.Cbuilds[2].BaseDir = "/solution/secure"
+		m.Cbuilds[2].BuildDescType.OutputDirs.Outdir = "/abs/out/secure"
+		m.Cbuilds[2].BuildDescType.Output = []maker.Output{{Type: "elf", File: "secure.elf"}, {Type: "cmse-lib", File: "secure_CMSE_Lib.o"}}
+		assert.Equal([]string{"${SOLUTION_ROOT}/out/lib/lib.a", "/abs/out/secure/secure_CMSE_Lib.o"}, m.DependencyLibraries(0))
+		assert.Empty(m.DependencyLibraries(1))
+	})
+
 	t.Run("test linker options", func(t *testing.T) {
 		var cbuild maker.Cbuild
 		cbuild.Languages = []string{"C", "CXX"}
@@ -584,10 +690,70 @@ add_dependencies(project.debug+target-executes
 		assert.Equal("${CMSIS_PACK_ROOT}/Vendor/PackName/1.2.3", cbuild.GetDpackDir())
 	})
 
+	t.Run("test group files with identical custom options", func(t *testing.T) {
+		files := []maker.Files{
+			{File: "a.c", Category: "sourceC", Define: []interface{}{"DEF"}, AddPath: []string{"inc"}},
+			{File: "b.c", Category: "sourceC"},
+			{File: "c.c", Category: "sourceC", Define: []interface{}{"DEF"}, AddPath: []string{"inc"}},
+			{File: "d.c", Category: "sourceC", Define: []interface{}{"DEF"}},
+			{File: "e.s", Category: "sourceAsm", Define: []interface{}{"ASM1"}, AddPathAsm: []string{"asm"}},
+			{File: "f.s", Category: "sourceAsm", Define: []interface{}{"ASM2"}, AddPathAsm: []string{"asm"}},
+		}
+		groups := maker.GroupCustomFiles(files)
+		assert.Len(groups, 3)
+		assert.Equal([]maker.Files{files[0], files[2]}, groups[maker.CustomOptionsKey(files[0])])
+		assert.Equal([]maker.Files{files[3]}, groups[maker.CustomOptionsKey(files[3])])
+		// asm defines are set in file properties and do not prevent sharing the target
+		assert.Equal([]maker.Files{files[4], files[5]}, groups[maker.CustomOptionsKey(files[4])])
+
+		var cbuild maker.Cbuild
+		cbuild.ContextRoot = "project"
+		content := cbuild.CMakeCreateCustomFilesTarget("Group", groups[maker.CustomOptionsKey(files[0])])
+		assert.Contains(content, "# files a.c c.c\nadd_library(Group_a_c OBJECT\n  \"${SOLUTION_ROOT}/project/a.c\"\n  \"${SOLUTION_ROOT}/project/c.c\"\n)")
+		assert.Equal([]string{"Group_a_c"}, cbuild.BuildGroups)
+	})
+
 	t.Run("test escape right angle bracket", func(t *testing.T) {
 		defines := []interface{}{
 			map[string]interface{}{"FOO": "A>B>C"},
 		}
 		assert.Equal("FOO=A$<ANGLE-R>B$<ANGLE-R>C", maker.ListCompileDefinitions(defines, ";"))
 	})
+
+	t.Run("test append global includes in scope order", func(t *testing.T) {
+		elements := maker.ScopeMap{
+			"PUBLIC":    {"C": {"public"}, "ALL": {"all"}},
+			"INTERFACE": {"C": {"interface"}},
+			"PRIVATE":   {"C": {"private"}},
+		}
+		for range 20 {
+			includes := maker.AppendGlobalIncludes(nil, elements)
+			assert.Equal(maker.LanguageMap{"C": {"interface", "public"}, "ALL": {"all"}}, includes)
+		}
+	})
+}
+
+func BenchmarkClassifyFiles(b *testing.B) {
+	var cbuild maker.Cbuild
+	cbuild.ContextRoot = "project"
+	var files []maker.Files
+	for index := range 20000 {
+		number := strconv.Itoa(index)
+		files = append(files,
+			maker.Files{File: "./source/file" + number + ".c", Category: "sourceC"},
+			maker.Files{File: "./include/dir" + number + "/file" + number + ".h", Category: "header"},
+			maker.Files{File: "./config/dir" + number + "/config" + number + ".h", Category: "header", Attr: "config"},
+		)
+	}
+	for b.Loop() {
+		cbuild.ClassifyFiles(files)
+	}
+}
+
+func BenchmarkReplaceDelimiters(b *testing.B) {
+	for b.Loop() {
+		for range 20000 {
+			maker.ReplaceDelimiters("ARM::Device:Startup&C Startup@2.2.0")
+		}
+	}
 }
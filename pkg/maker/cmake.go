@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker
+
+import (
+	"strings"
+)
+
+// CMake emission helpers, generated commands and arguments are built with these
+// functions to get consistent quoting and escaping of file names and values
+
+var (
+	quoteEscaper   = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	literalEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "${", `\${`, "$ENV{", `\$ENV{`, "$CACHE{", `\$CACHE{`)
+	genexEscaper   = strings.NewReplacer(">", "$<ANGLE-R>", ",", "$<COMMA>", ";", "$<SEMICOLON>")
+)
+
+// CMakeQuote returns a quoted argument, variable references, generator expressions
+// and list separators in the value are kept
+func CMakeQuote(value string) string {
+	return "\"" + quoteEscaper.Replace(value) + "\""
+}
+
+// CMakeLiteral returns a quoted argument holding the value literally,
+// list separators are kept
+func CMakeLiteral(value string) string {
+	return "\"" + literalEscaper.Replace(value) + "\""
+}
+
+// CMakeArgument returns an unquoted argument holding the value literally when possible,
+// otherwise a quoted one
+func CMakeArgument(value string) string {
+	if len(value) == 0 || strings.HasPrefix(value, "[") || strings.ContainsAny(value, " \t\r\n()#\"\\;") ||
+		literalEscaper.Replace(value) != value {
+		return CMakeLiteral(value)
+	}
+	return value
+}
+
+// CMakeBracket returns a bracket argument holding the value literally without any evaluation
+func CMakeBracket(value string) string {
+	equals := ""
+	for strings.Contains(value, "]"+equals+"]") || strings.HasSuffix(value, "]"+equals) {
+		equals += "="
+	}
+	// a newline directly following the opening bracket is ignored
+	if strings.HasPrefix(value, "\n") || strings.HasPrefix(value, "\r\n") {
+		value = "\n" + value
+	}
+	return "[" + equals + "[" + value + "]" + equals + "]"
+}
+
+// CMakeGenexEscape escapes the characters terminating or splitting a generator expression
+func CMakeGenexEscape(value string) string {
+	return genexEscaper.Replace(value)
+}
+
+// CMakeGenex returns the generator expression with the given name and arguments
+func CMakeGenex(name string, arguments ...string) string {
+	if len(arguments) == 0 {
+		return "$<" + name + ">"
+	}
+	return "$<" + name + ":" + strings.Join(arguments, ",") + ">"
+}
+
+// CMakeCommand returns a command invocation with the leading arguments on the first line
+// followed by one argument per line
+func CMakeCommand(name string, leading []string, arguments ...string) string {
+	var content strings.Builder
+	content.WriteString("\n" + name + "(" + strings.Join(leading, " "))
+	for _, argument := range arguments {
+		content.WriteString("\n  " + argument)
+	}
+	content.WriteString("\n)")
+	return content.String()
+}
+
+// CMakeQuoteAll quotes each of the values
+func CMakeQuoteAll(values []string) []string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, CMakeQuote(value))
+	}
+	return quoted
+}
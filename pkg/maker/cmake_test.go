@@ -0,0 +1,193 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"errors"
+	"math/rand/v2"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	"github.com/stretchr/testify/assert"
+)
+
+// parseArgument evaluates a single quoted, bracket or unquoted CMake argument,
+// variable references are reported as error since they would be expanded
+func parseArgument(argument string) (string, error) {
+	if strings.HasPrefix(argument, "[") {
+		equals := strings.TrimLeft(argument[1:], "=")
+		equals = argument[1 : len(argument)-len(equals)]
+		open, close := "["+equals+"[", "]"+equals+"]"
+		if !strings.HasPrefix(argument, open) || !strings.HasSuffix(argument, close) ||
+			strings.Contains(argument[len(open):len(argument)-len(close)], close) {
+			return "", errors.New("invalid bracket argument")
+		}
+		content := argument[len(open) : len(argument)-len(close)]
+		content = strings.TrimPrefix(strings.TrimPrefix(content, "\r"), "\n")
+		return content, nil
+	}
+	quoted := strings.HasPrefix(argument, "\"")
+	if quoted {
+		if len(argument) < 2 || !strings.HasSuffix(argument, "\"") {
+			return "", errors.New("invalid quoted argument")
+		}
+		argument = argument[1 : len(argument)-1]
+	}
+	var value strings.Builder
+	for index := 0; index < len(argument); index++ {
+		char := argument[index]
+		switch {
+		case char == '\\':
+			index++
+			if index == len(argument) {
+				return "", errors.New("trailing backslash")
+			}
+			switch escaped := argument[index]; escaped {
+			case 't':
+				value.WriteByte('\t')
+			case 'n':
+				value.WriteByte('\n')
+			case 'r':
+				value.WriteByte('\r')
+			case ';':
+				value.WriteString("\\;")
+			default:
+				if strings.ContainsRune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789", rune(escaped)) {
+					return "", errors.New("invalid escape sequence")
+				}
+				value.WriteByte(escaped)
+			}
+		case char == '$' && (strings.HasPrefix(argument[index:], "${") || strings.HasPrefix(argument[index:], "$ENV{") ||
+			strings.HasPrefix(argument[index:], "$CACHE{")):
+			return "", errors.New("variable reference")
+		case char == '"' && quoted:
+			return "", errors.New("unescaped quote")
+		case !quoted && strings.ContainsRune(" \t\r\n()#\";", rune(char)):
+			return "", errors.New("invalid character in unquoted argument")
+		default:
+			value.WriteByte(char)
+		}
+	}
+	return value.String(), nil
+}
+
+func randomValue(random *rand.Rand) string {
+	alphabet := []string{"a", "Z", "0", "_", "-", "/", ".", "=", " ", "\t", "\n", "\"", "\\", "$", "{", "}", "${", "$ENV{", "(", ")",
+		"#", ";", ",", ">", "<", "$<", "[", "]", "[[", "]]", "]=]", "[=[", "@", "^", "'", "ü"}
+	var value strings.Builder
+	for range random.IntN(12) {
+		value.WriteString(alphabet[random.IntN(len(alphabet))])
+	}
+	return value.String()
+}
+
+func TestCMake(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("test quote", func(t *testing.T) {
+		assert.Equal(`"${SOLUTION_ROOT}/main.c"`, maker.CMakeQuote("${SOLUTION_ROOT}/main.c"))
+		assert.Equal(`"SHELL:${_LS}\"${LD_SCRIPT_PP}\""`, maker.CMakeQuote(`SHELL:${_LS}"${LD_SCRIPT_PP}"`))
+		assert.Equal(`"C:\\path\\file.c"`, maker.CMakeQuote(`C:\path\file.c`))
+		assert.Equal(`"a;b"`, maker.CMakeQuote("a;b"))
+	})
+
+	t.Run("test literal", func(t *testing.T) {
+		assert.Equal(`"\${HOME} \"quoted\" \\"`, maker.CMakeLiteral(`${HOME} "quoted" \`))
+		assert.Equal(`"$<ANGLE-R>"`, maker.CMakeLiteral("$<ANGLE-R>"))
+	})
+
+	t.Run("test argument", func(t *testing.T) {
+		assert.Equal("DEF_KEY=VALUE", maker.CMakeArgument("DEF_KEY=VALUE"))
+		assert.Equal("A$<ANGLE-R>B", maker.CMakeArgument("A$<ANGLE-R>B"))
+		assert.Equal(`"STRING=\"String\""`, maker.CMakeArgument(`STRING="String"`))
+		assert.Equal(`"A B"`, maker.CMakeArgument("A B"))
+		assert.Equal(`"A#B"`, maker.CMakeArgument("A#B"))
+		assert.Equal(`"A;B"`, maker.CMakeArgument("A;B"))
+		assert.Equal(`"\${A}"`, maker.CMakeArgument("${A}"))
+		assert.Equal(`""`, maker.CMakeArgument(""))
+	})
+
+	t.Run("test bracket", func(t *testing.T) {
+		assert.Equal("[[text]]", maker.CMakeBracket("text"))
+		assert.Equal("[=[a]]b]=]", maker.CMakeBracket("a]]b"))
+		assert.Equal("[==[a]=]b]]]==]", maker.CMakeBracket("a]=]b]]"))
+		assert.Equal("[=[a]]=]", maker.CMakeBracket("a]"))
+		assert.Equal("[[\n\nline]]", maker.CMakeBracket("\nline"))
+	})
+
+	t.Run("test generator expressions", func(t *testing.T) {
+		assert.Equal("$<COMPILE_LANGUAGE:C,CXX>", maker.CMakeGenex("COMPILE_LANGUAGE", "C", "CXX"))
+		assert.Equal("$<CONFIG>", maker.CMakeGenex("CONFIG"))
+		assert.Equal("a$<ANGLE-R>b$<COMMA>c$<SEMICOLON>d", maker.CMakeGenexEscape("a>b,c;d"))
+	})
+
+	t.Run("test command", func(t *testing.T) {
+		assert.Equal("\nadd_library(name OBJECT\n  \"a.c\"\n  \"b c.c\"\n)",
+			maker.CMakeCommand("add_library", []string{"name", "OBJECT"}, maker.CMakeQuoteAll([]string{"a.c", "b c.c"})...))
+	})
+
+	t.Run("test compile definitions", func(t *testing.T) {
+		defines := []interface{}{
+			"PLAIN",
+			map[string]interface{}{"STRING": `"a b"`},
+			map[string]interface{}{"LIST": "a;b,c"},
+			map[string]interface{}{"REF": "${HOME}"},
+		}
+		assert.Equal(`PLAIN
+"STRING=\"a b\""
+LIST=a$<SEMICOLON>b$<COMMA>c
+"REF=\${HOME}"`, maker.ListCompileDefinitions(defines, "\n"))
+	})
+
+	t.Run("test round trip of tricky values", func(t *testing.T) {
+		random := rand.New(rand.NewPCG(1, 2))
+		for range 2000 {
+			value := randomValue(random)
+			for name, encode := range map[string]func(string) string{
+				"literal": maker.CMakeLiteral, "argument": maker.CMakeArgument, "bracket": maker.CMakeBracket,
+			} {
+				decoded, err := parseArgument(encode(value))
+				assert.Nil(err, name+": %q", value)
+				assert.Equal(value, decoded, name+": %q", value)
+			}
+			escaped := maker.CMakeGenexEscape(value)
+			assert.False(strings.ContainsAny(strings.NewReplacer("$<ANGLE-R>", "", "$<COMMA>", "", "$<SEMICOLON>", "").Replace(escaped), ">,;"))
+		}
+	})
+
+	t.Run("test round trip through cmake", func(t *testing.T) {
+		cmake, err := exec.LookPath("cmake")
+		if err != nil {
+			t.Skip("cmake not found")
+		}
+		random := rand.New(rand.NewPCG(3, 4))
+		var values []string
+		var script strings.Builder
+		for range 200 {
+			value := randomValue(random)
+			values = append(values, value)
+			for _, argument := range []string{maker.CMakeLiteral(value), maker.CMakeArgument(value), maker.CMakeBracket(value)} {
+				script.WriteString("file(APPEND \"${OUT}\" " + argument + ")\nfile(APPEND \"${OUT}\" \"<END>\")\n")
+			}
+		}
+		dir := t.TempDir()
+		assert.Nil(os.WriteFile(path.Join(dir, "script.cmake"), []byte(script.String()), 0644))
+		output, err := exec.Command(cmake, "-DOUT="+path.Join(dir, "out.txt"), "-P", path.Join(dir, "script.cmake")).CombinedOutput()
+		assert.Nil(err, string(output))
+		content, err := os.ReadFile(path.Join(dir, "out.txt"))
+		assert.Nil(err)
+		results := strings.Split(strings.TrimSuffix(string(content), "<END>"), "<END>")
+		assert.Len(results, 3*len(values))
+		for index, result := range results {
+			assert.Equal(values[index/3], result)
+		}
+	})
+}
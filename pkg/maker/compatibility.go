@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker
+
+import (
+	"errors"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Ranks of processor features, a library must not use more than its consumer provides
+var fpuRank = map[string]int{"": 0, "off": 0, "sp": 1, "dp": 2}
+var mveRank = map[string]int{"": 0, "off": 0, "int": 1, "fp": 2}
+
+// CheckLinkCompatibility verifies that contexts linking lib or cmse-lib outputs of
+// their dependencies are built for a link compatible processor configuration
+func (m *Maker) CheckLinkCompatibility() error {
+	var incompatibilities []string
+	for index := range m.Cbuilds {
+		for _, dependency := range m.DependencyContexts(index) {
+			errs, warnings := m.LinkIncompatibilities(index, dependency)
+			for _, warning := range warnings {
+				log.Warn(warning)
+			}
+			incompatibilities = append(incompatibilities, errs...)
+		}
+	}
+	if len(incompatibilities) > 0 {
+		return errors.New("incompatible contexts are linked:\n  " + strings.Join(incompatibilities, "\n  "))
+	}
+	return nil
+}
+
+// LinkIncompatibilities compares a consumer context with a dependency context whose
+// lib or cmse-lib output it links, mismatches breaking the ABI are reported as errors
+func (m *Maker) LinkIncompatibilities(consumer int, dependency int) (errs []string, warnings []string) {
+	var outputTypes []string
+	for _, output := range m.Cbuilds[dependency].BuildDescType.Output {
+		if IsLinkableOutput(output.Type) {
+			outputTypes = append(outputTypes, output.Type)
+		}
+	}
+	if len(outputTypes) == 0 {
+		return
+	}
+	context := m.Cbuilds[consumer].BuildDescType.Context
+	library := m.Cbuilds[dependency].BuildDescType.Context
+	image := m.Cbuilds[consumer].BuildDescType.Processor
+	lib := m.Cbuilds[dependency].BuildDescType.Processor
+	mismatch := func(what string) string {
+		return context + " links " + library + ": " + what
+	}
+
+	// Byte order
+	if endianness(lib.Endian) != endianness(image.Endian) {
+		errs = append(errs, mismatch(endianness(lib.Endian)+"-endian library, "+endianness(image.Endian)+"-endian image"))
+	}
+
+	// Floating point ABI and instructions
+	if floatABI(lib.Fpu) != floatABI(image.Fpu) {
+		errs = append(errs, mismatch(floatABI(lib.Fpu)+"-float library, "+floatABI(image.Fpu)+"-float image"))
+	} else if fpuRank[lib.Fpu] > fpuRank[image.Fpu] {
+		errs = append(errs, mismatch("library uses fpu '"+lib.Fpu+"', image provides '"+image.Fpu+"'"))
+	}
+
+	// Instruction set extensions
+	if lib.Dsp == "on" && image.Dsp != "on" {
+		errs = append(errs, mismatch("library uses dsp instructions, image is built without dsp"))
+	}
+	if mveRank[lib.Mve] > mveRank[image.Mve] {
+		errs = append(errs, mismatch("library uses mve '"+lib.Mve+"', image provides '"+valueOrOff(image.Mve)+"'"))
+	}
+
+	// TrustZone
+	for _, outputType := range outputTypes {
+		switch outputType {
+		case "lib":
+			if isSecure(lib.Trustzone) && !isSecure(image.Trustzone) {
+				errs = append(errs, mismatch("secure library '"+lib.Trustzone+"', image is '"+valueOrOff(image.Trustzone)+"'"))
+			}
+		case "cmse-lib":
+			if image.Trustzone != "non-secure" {
+				errs = append(errs, mismatch("CMSE import library requires a non-secure image, image is '"+valueOrOff(image.Trustzone)+"'"))
+			}
+		}
+	}
+
+	// Branch protection: a library without it weakens the protection of the image
+	if valueOrOff(image.BranchProtection) != "off" && valueOrOff(lib.BranchProtection) == "off" {
+		warnings = append(warnings, mismatch("library is built without branch-protection, image uses '"+image.BranchProtection+"'"))
+	}
+
+	// Core and compiler
+	if len(lib.Core) > 0 && len(image.Core) > 0 && lib.Core != image.Core {
+		warnings = append(warnings, mismatch("library is built for core "+lib.Core+", image for "+image.Core))
+	}
+	if len(m.SelectedToolchainVersion) > max(consumer, dependency) {
+		libToolchain := m.RegisteredToolchains[m.SelectedToolchainVersion[dependency]].Name
+		imageToolchain := m.RegisteredToolchains[m.SelectedToolchainVersion[consumer]].Name
+		if libToolchain != imageToolchain {
+			warnings = append(warnings, mismatch("library is built with "+libToolchain+", image with "+imageToolchain))
+		}
+	}
+	return errs, warnings
+}
+
+func endianness(endian string) string {
+	if endian == "big" {
+		return "big"
+	}
+	return "little"
+}
+
+func floatABI(fpu string) string {
+	if fpuRank[fpu] > 0 {
+		return "hard"
+	}
+	return "soft"
+}
+
+func isSecure(trustzone string) bool {
+	return trustzone == "secure" || trustzone == "secure-only"
+}
+
+func valueOrOff(value string) string {
+	if len(value) == 0 {
+		return "off"
+	}
+	return value
+}
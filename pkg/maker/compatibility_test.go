@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompatibility(t *testing.T) {
+	assert := assert.New(t)
+
+	newMaker := func(image maker.Processor, library maker.Processor, outputType string) maker.Maker {
+		var m maker.Maker
+		m.CbuildIndex.BuildIdx.Cbuilds = []maker.Cbuilds{
+			{Project: "app", Configuration: ".debug+target", DependsOn: []string{"lib.debug+target"}},
+			{Project: "lib", Configuration: ".debug+target"},
+		}
+		m.Cbuilds = make([]maker.Cbuild, 2)
+		m.Cbuilds[0].BuildDescType.Context = "app.debug+target"
+		m.Cbuilds[0].BuildDescType.Processor = image
+		m.Cbuilds[0].BuildDescType.Output = []maker.Output{{Type: "elf", File: "app.elf"}}
+		m.Cbuilds[1].BuildDescType.Context = "lib.debug+target"
+		m.Cbuilds[1].BuildDescType.Processor = library
+		m.Cbuilds[1].BuildDescType.Output = []maker.Output{{Type: outputType, File: "lib.a"}}
+		return m
+	}
+
+	t.Run("test compatible contexts", func(t *testing.T) {
+		m := newMaker(maker.Processor{Core: "Cortex-M33", Fpu: "dp", Dsp: "on"}, maker.Processor{Core: "Cortex-M33", Fpu: "sp"}, "lib")
+		errs, warnings := m.LinkIncompatibilities(0, 1)
+		assert.Empty(errs)
+		assert.Empty(warnings)
+		assert.Nil(m.CheckLinkCompatibility())
+	})
+
+	t.Run("test float abi and byte order mismatch", func(t *testing.T) {
+		m := newMaker(maker.Processor{Core: "Cortex-M4", Fpu: "off"}, maker.Processor{Core: "Cortex-M4", Fpu: "sp", Endian: "big"}, "lib")
+		errs, _ := m.LinkIncompatibilities(0, 1)
+		assert.Equal([]string{
+			"app.debug+target links lib.debug+target: big-endian library, little-endian image",
+			"app.debug+target links lib.debug+target: hard-float library, soft-float image",
+		}, errs)
+		err := m.CheckLinkCompatibility()
+		assert.Error(err)
+		assert.Contains(err.Error(), "incompatible contexts are linked:\n  app.debug+target links lib.debug+target: big-endian library")
+	})
+
+	t.Run("test instruction set extensions mismatch", func(t *testing.T) {
+		m := newMaker(maker.Processor{Fpu: "sp"}, maker.Processor{Fpu: "dp", Dsp: "on", Mve: "fp"}, "lib")
+		errs, _ := m.LinkIncompatibilities(0, 1)
+		assert.Equal([]string{
+			"app.debug+target links lib.debug+target: library uses fpu 'dp', image provides 'sp'",
+			"app.debug+target links lib.debug+target: library uses dsp instructions, image is built without dsp",
+			"app.debug+target links lib.debug+target: library uses mve 'fp', image provides 'off'",
+		}, errs)
+	})
+
+	t.Run("test trustzone mismatch", func(t *testing.T) {
+		m := newMaker(maker.Processor{}, maker.Processor{Trustzone: "secure-only"}, "lib")
+		errs, _ := m.LinkIncompatibilities(0, 1)
+		assert.Equal([]string{"app.debug+target links lib.debug+target: secure library 'secure-only', image is 'off'"}, errs)
+
+		m = newMaker(maker.Processor{Trustzone: "secure"}, maker.Processor{Trustzone: "secure"}, "cmse-lib")
+		errs, _ = m.LinkIncompatibilities(0, 1)
+		assert.Equal([]string{"app.debug+target links lib.debug+target: CMSE import library requires a non-secure image, image is 'secure'"}, errs)
+
+		m = newMaker(maker.Processor{Trustzone: "non-secure"}, maker.Processor{Trustzone: "secure"}, "cmse-lib")
+		errs, _ = m.LinkIncompatibilities(0, 1)
+		assert.Empty(errs)
+	})
+
+	t.Run("test warnings", func(t *testing.T) {
+		m := newMaker(maker.Processor{Core: "Cortex-M55", BranchProtection: "bti"}, maker.Processor{Core: "Cortex-M33"}, "lib")
+		errs, warnings := m.LinkIncompatibilities(0, 1)
+		assert.Empty(errs)
+		assert.Equal([]string{
+			"app.debug+target links lib.debug+target: library is built without branch-protection, image uses 'bti'",
+			"app.debug+target links lib.debug+target: library is built for core Cortex-M33, image for Cortex-M55",
+		}, warnings)
+		assert.Nil(m.CheckLinkCompatibility())
+	})
+
+	t.Run("test dependency without library output", func(t *testing.T) {
+		m := newMaker(maker.Processor{Fpu: "off"}, maker.Processor{Fpu: "sp"}, "hex")
+		errs, warnings := m.LinkIncompatibilities(0, 1)
+		assert.Empty(errs)
+		assert.Empty(warnings)
+	})
+}
@@ -0,0 +1,185 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const ProjectConfigFileName = "cbuild2cmake.yml"
+
+// Environment variable overriding the location of the user configuration file
+const UserConfigEnvVar = "CBUILD2CMAKE_USER_CONFIG"
+
+// Default CMake generator of the context builds
+const DefaultGenerator = "Ninja"
+
+// ProjectConfig is read from the optional cbuild2cmake.yml next to the csolution and from
+// the user configuration file, project settings take precedence over user settings
+type ProjectConfig struct {
+	Options          OptionsConfig     `yaml:"options,omitempty"`
+	Generator        string            `yaml:"generator,omitempty"`
+	OutputConverters []OutputConverter `yaml:"output-converters,omitempty"`
+	CombinedImages   []CombinedImage   `yaml:"combined-images,omitempty"`
+	Hooks            []Hook            `yaml:"hooks,omitempty"`
+}
+
+// OptionsConfig holds defaults of the command line options, options not given in
+// a configuration file are nil, quiet and debug are only taken from the command line
+type OptionsConfig struct {
+	Verbose          *bool `yaml:"verbose,omitempty"`
+	UseContextSet    *bool `yaml:"context-set,omitempty"`
+	Zephyr           *bool `yaml:"zephyr,omitempty"`
+	SharedComponents *bool `yaml:"shared-components,omitempty"`
+	ExportPackages   *bool `yaml:"export-packages,omitempty"`
+	TrustZoneImage   *bool `yaml:"trustzone-image,omitempty"`
+	StrictExecutes   *bool `yaml:"strict-executes,omitempty"`
+	ContextExecutes  *bool `yaml:"context-executes,omitempty"`
+	Relocatable      *bool `yaml:"relocatable,omitempty"`
+	PrefixMap        *bool `yaml:"prefix-map,omitempty"`
+	Incremental      *bool `yaml:"incremental,omitempty"`
+	Jobs             *int  `yaml:"jobs,omitempty"`
+}
+
+// Apply sets the options given in the configuration unless they are set on the command line
+func (c OptionsConfig) Apply(options *Options, flags []string) {
+	applyOption(c.Verbose, &options.Verbose, "verbose", flags)
+	applyOption(c.UseContextSet, &options.UseContextSet, "context-set", flags)
+	applyOption(c.Zephyr, &options.Zephyr, "zephyr", flags)
+	applyOption(c.SharedComponents, &options.SharedComponents, "shared-components", flags)
+	applyOption(c.ExportPackages, &options.ExportPackages, "export-packages", flags)
+	applyOption(c.TrustZoneImage, &options.TrustZoneImage, "trustzone-image", flags)
+	applyOption(c.StrictExecutes, &options.StrictExecutes, "strict-executes", flags)
+	applyOption(c.ContextExecutes, &options.ContextExecutes, "context-executes", flags)
+	applyOption(c.Relocatable, &options.Relocatable, "relocatable", flags)
+	applyOption(c.PrefixMap, &options.PrefixMap, "prefix-map", flags)
+	applyOption(c.Incremental, &options.Incremental, "incremental", flags)
+	applyOption(c.Jobs, &options.Jobs, "jobs", flags)
+}
+
+func applyOption[T any](value *T, option *T, name string, flags []string) {
+	if value != nil && !slices.Contains(flags, name) {
+		*option = *value
+	}
+}
+
+// EffectiveOptions returns the options in configuration form
+func EffectiveOptions(options Options) OptionsConfig {
+	return OptionsConfig{
+		Verbose:          &options.Verbose,
+		UseContextSet:    &options.UseContextSet,
+		Zephyr:           &options.Zephyr,
+		SharedComponents: &options.SharedComponents,
+		ExportPackages:   &options.ExportPackages,
+		TrustZoneImage:   &options.TrustZoneImage,
+		StrictExecutes:   &options.StrictExecutes,
+		ContextExecutes:  &options.ContextExecutes,
+		Relocatable:      &options.Relocatable,
+		PrefixMap:        &options.PrefixMap,
+		Incremental:      &options.Incremental,
+		Jobs:             &options.Jobs,
+	}
+}
+
+// Generator returns the CMake generator of the context builds
+func (m *Maker) Generator() string {
+	if len(m.ProjectConfig.Generator) == 0 {
+		return DefaultGenerator
+	}
+	return m.ProjectConfig.Generator
+}
+
+func (m *Maker) ParseProjectConfigFile(configFile string) (data ProjectConfig, err error) {
+	yfile, err := os.ReadFile(configFile)
+	if err != nil {
+		return
+	}
+	err = yaml.Unmarshal(yfile, &data)
+	return
+}
+
+// UserConfigFile returns the path of the user configuration file
+func UserConfigFile() string {
+	if configFile, ok := os.LookupEnv(UserConfigEnvVar); ok {
+		return configFile
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.ToSlash(filepath.Join(configDir, "cbuild2cmake", ProjectConfigFileName))
+}
+
+func (m *Maker) LoadProjectConfig() error {
+	// Optional user configuration
+	var userConfig ProjectConfig
+	if configFile := UserConfigFile(); len(configFile) > 0 {
+		if _, err := os.Stat(configFile); err == nil {
+			config, err := m.ParseProjectConfigFile(configFile)
+			if err != nil {
+				return err
+			}
+			userConfig = config
+			log.Debug("Found user config file: " + configFile)
+		}
+	}
+
+	// Optional project configuration next to the csolution
+	configFile := path.Join(m.SolutionRoot, ProjectConfigFileName)
+	if _, err := os.Stat(configFile); err == nil {
+		config, err := m.ParseProjectConfigFile(configFile)
+		if err != nil {
+			return err
+		}
+		m.ProjectConfig = config
+		log.Debug("Found project config file: " + configFile)
+	}
+
+	// Options: flag > project > user
+	userConfig.Options.Apply(&m.Options, m.Flags)
+	m.ProjectConfig.Options.Apply(&m.Options, m.Flags)
+	m.ProjectConfig.Options = EffectiveOptions(m.Options)
+	if len(m.ProjectConfig.Generator) == 0 {
+		m.ProjectConfig.Generator = userConfig.Generator
+	}
+	m.ProjectConfig.Generator = m.Generator()
+
+	// Hooks and combined images: user entries first, project entries appended,
+	// a project image replaces the user image of the same name
+	m.ProjectConfig.Hooks = append(slices.Clip(userConfig.Hooks), m.ProjectConfig.Hooks...)
+	var combinedImages []CombinedImage
+	for _, image := range userConfig.CombinedImages {
+		if !slices.ContainsFunc(m.ProjectConfig.CombinedImages, func(item CombinedImage) bool { return item.Name == image.Name }) {
+			combinedImages = append(combinedImages, image)
+		}
+	}
+	m.ProjectConfig.CombinedImages = append(combinedImages, m.ProjectConfig.CombinedImages...)
+
+	// Output converters
+	m.OutputConverters = DefaultOutputConverters()
+	m.OutputConverters.Register(userConfig.OutputConverters)
+	m.OutputConverters.Register(m.ProjectConfig.OutputConverters)
+
+	// Debug
+	if m.Options.Debug {
+		effectiveConfig := m.ProjectConfig
+		effectiveConfig.OutputConverters = userConfig.OutputConverters
+		effectiveConfig.OutputConverters = append(effectiveConfig.OutputConverters, m.ProjectConfig.OutputConverters...)
+		content, _ := yaml.Marshal(effectiveConfig)
+		log.Debug("Effective configuration:\n" + string(content))
+	}
+
+	// User CMake hooks
+	return m.ValidateHooks()
+}
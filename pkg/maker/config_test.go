@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("GCC_TOOLCHAIN_12_3_0", testRoot+"/run/path/to/gcc1230/bin")
+
+	writeConfigs := func(t *testing.T, userConfig string, projectConfig string) string {
+		inputFile := createSyntheticSolution(t, 1, 1)
+		userConfigFile := path.Join(t.TempDir(), maker.ProjectConfigFileName)
+		assert.Nil(os.WriteFile(userConfigFile, []byte(userConfig), 0644))
+		t.Setenv(maker.UserConfigEnvVar, userConfigFile)
+		if len(projectConfig) > 0 {
+			assert.Nil(os.WriteFile(path.Join(path.Dir(inputFile), maker.ProjectConfigFileName), []byte(projectConfig), 0644))
+		}
+		return inputFile
+	}
+
+	t.Run("test option precedence", func(t *testing.T) {
+		inputFile := writeConfigs(t,
+			"options:\n  verbose: true\n  jobs: 2\n  shared-components: true\ngenerator: Unix Makefiles\n",
+			"options:\n  jobs: 4\n  strict-executes: true\n")
+		var m maker.Maker
+		m.Params.InputFile = inputFile
+		m.Params.Flags = []string{"shared-components"}
+		m.Params.Options.SharedComponents = false
+		m.Writer = maker.NewFileWriter()
+		assert.Nil(m.GenerateCMakeLists())
+		assert.True(m.Options.Verbose)
+		assert.Equal(4, m.Options.Jobs)
+		assert.True(m.Options.StrictExecutes)
+		assert.False(m.Options.SharedComponents)
+		assert.Equal("Unix Makefiles", m.Generator())
+		assert.Contains(m.Writer.Files[path.Join(m.SolutionTmpDir, "CMakeLists.txt")],
+			"CONFIGURE_COMMAND     ${CMAKE_COMMAND} -G \"Unix Makefiles\" -S <SOURCE_DIR>")
+	})
+
+	t.Run("test defaults without configuration files", func(t *testing.T) {
+		inputFile := createSyntheticSolution(t, 1, 1)
+		t.Setenv(maker.UserConfigEnvVar, "")
+		var m maker.Maker
+		m.Params.InputFile = inputFile
+		m.Writer = maker.NewFileWriter()
+		assert.Nil(m.GenerateCMakeLists())
+		assert.Equal(maker.DefaultGenerator, m.Generator())
+		assert.Equal(0, m.Options.Jobs)
+		assert.Contains(m.Writer.Files[path.Join(m.SolutionTmpDir, "CMakeLists.txt")],
+			"CONFIGURE_COMMAND     ${CMAKE_COMMAND} -G Ninja -S <SOURCE_DIR>")
+	})
+
+	t.Run("test effective configuration in debug messages", func(t *testing.T) {
+		inputFile := writeConfigs(t, "options:\n  relocatable: true\n", "generator: Ninja Multi-Config\n")
+		t.Setenv("CMSIS_PACK_ROOT", t.TempDir())
+		var buffer bytes.Buffer
+		output, level := log.StandardLogger().Out, log.GetLevel()
+		log.SetOutput(&buffer)
+		log.SetLevel(log.DebugLevel)
+		defer func() {
+			log.SetOutput(output)
+			log.SetLevel(level)
+		}()
+		var m maker.Maker
+		m.Params.InputFile = inputFile
+		m.Params.Options.Debug = true
+		m.Writer = maker.NewFileWriter()
+		assert.Nil(m.GenerateCMakeLists())
+		assert.Contains(buffer.String(), "Effective configuration:")
+		assert.Contains(buffer.String(), "relocatable: true")
+		assert.Contains(buffer.String(), "generator: Ninja Multi-Config")
+	})
+
+	t.Run("test hooks and combined images of user and project", func(t *testing.T) {
+		inputFile := writeConfigs(t,
+			"hooks:\n  - file: user.cmake\n    point: after-target\n"+
+				"combined-images:\n  - name: firmware\n    images:\n      - file: user.bin@0x0\n"+
+				"  - name: user\n    images:\n      - file: user.hex\n",
+			"hooks:\n  - file: project.cmake\n    point: after-target\n"+
+				"combined-images:\n  - name: firmware\n    images:\n      - file: project.hex\n")
+		solutionRoot := path.Dir(inputFile)
+		assert.Nil(os.WriteFile(path.Join(solutionRoot, "user.cmake"), []byte(""), 0644))
+		assert.Nil(os.WriteFile(path.Join(solutionRoot, "project.cmake"), []byte(""), 0644))
+		var buffer bytes.Buffer
+		output, level := log.StandardLogger().Out, log.GetLevel()
+		log.SetOutput(&buffer)
+		log.SetLevel(log.DebugLevel)
+		defer func() {
+			log.SetOutput(output)
+			log.SetLevel(level)
+		}()
+		var m maker.Maker
+		m.Params.InputFile = inputFile
+		m.Params.Options.Debug = true
+		assert.Nil(m.ParseCbuildIndex())
+		assert.Nil(m.LoadProjectConfig())
+		assert.Equal([]maker.Hook{
+			{File: "user.cmake", Point: maker.HookAfterTarget},
+			{File: "project.cmake", Point: maker.HookAfterTarget},
+		}, m.ProjectConfig.Hooks)
+		assert.Len(m.ProjectConfig.CombinedImages, 2)
+		assert.Equal("user", m.ProjectConfig.CombinedImages[0].Name)
+		assert.Equal("firmware", m.ProjectConfig.CombinedImages[1].Name)
+		assert.Equal("project.hex", m.ProjectConfig.CombinedImages[1].Images[0].File)
+		assert.Contains(buffer.String(), "hooks:\n    - file: user.cmake\n      point: after-target\n      context: \"\"\n    - file: project.cmake")
+		assert.Contains(buffer.String(), "combined-images:\n    - name: user")
+	})
+
+	t.Run("test configuration in lint and graph", func(t *testing.T) {
+		inputFile := createSyntheticSolution(t, 2, 1)
+		t.Setenv(maker.UserConfigEnvVar, "")
+		cbuildSet := "cbuild-set:\n  contexts:\n    - context: project.GCC+Target1\n"
+		assert.Nil(os.WriteFile(path.Join(path.Dir(inputFile), "solution.cbuild-set.yml"), []byte(cbuildSet), 0644))
+		assert.Nil(os.WriteFile(path.Join(path.Dir(inputFile), maker.ProjectConfigFileName), []byte("options:\n  context-set: true\n"), 0644))
+		var lint maker.Maker
+		lint.Params.InputFile = inputFile
+		_, err := lint.Lint()
+		assert.Nil(err)
+		assert.True(lint.Options.UseContextSet)
+		assert.Len(lint.Cbuilds, 1)
+
+		var graph maker.Maker
+		graph.Params.InputFile = inputFile
+		content, err := graph.GenerateGraph("dot")
+		assert.Nil(err)
+		assert.Contains(content, "project.GCC+Target1")
+		assert.NotContains(content, "project.GCC+Target0")
+	})
+
+	t.Run("test invalid user configuration", func(t *testing.T) {
+		inputFile := writeConfigs(t, "options: [", "")
+		var m maker.Maker
+		m.Params.InputFile = inputFile
+		m.Writer = maker.NewFileWriter()
+		assert.Error(m.GenerateCMakeLists())
+	})
+}
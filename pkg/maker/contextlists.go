@@ -9,21 +9,36 @@ package maker
 import (
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 	"unicode"
 
 	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
+	sortedmap "github.com/gobs/sortedmap"
 	"golang.org/x/exp/maps"
 )
 
+// CreateContextsCMakeLists creates the CMake files of all contexts concurrently,
+// each worker only modifies the cbuild of its own context
+func (m *Maker) CreateContextsCMakeLists() error {
+	return utils.ParallelFor(len(m.Cbuilds), m.Workers(), func(index int) error {
+		if m.CbuildIndex.BuildIdx.Cbuilds[index].West {
+			return m.CreateWestCMakeLists(index)
+		}
+		return m.CreateContextCMakeLists(index)
+	})
+}
+
 func (m *Maker) CreateContextCMakeLists(index int) error {
 	cbuild := &m.Cbuilds[index]
-	outputByProducts, outputFile, outputType, customCommands := OutputFiles(cbuild.BuildDescType.Output)
-	outputExt := path.Ext(outputFile)
-	outputName := strings.TrimSuffix(outputFile, outputExt)
+	cbuild.Writer = m.Writer
 	cbuild.ContextRoot, _ = filepath.Rel(m.SolutionRoot, cbuild.BaseDir)
 	cbuild.ContextRoot = filepath.ToSlash(cbuild.ContextRoot)
 	cbuild.Toolchain = m.RegisteredToolchains[m.SelectedToolchainVersion[index]].Name
+	cbuild.PrefixMap = m.Options.PrefixMap
+	outputByProducts, outputFile, outputType, customCommands := OutputFiles(cbuild.BuildDescType.Output, cbuild.Toolchain, m.OutputConverters)
+	outputExt := path.Ext(outputFile)
+	outputName := strings.TrimSuffix(outputFile, outputExt)
 	outDir := cbuild.AddRootPrefix(cbuild.ContextRoot, cbuild.BuildDescType.OutputDirs.Outdir)
 	contextDir := path.Join(m.SolutionTmpDir, cbuild.BuildDescType.Context)
 	cbuild.IncludeGlobal = make(LanguageMap)
@@ -59,8 +74,21 @@ func (m *Maker) CreateContextCMakeLists(index int) error {
 	}
 
 	// Linker options
+	var dependencyLibraries string
 	if outputType == "elf" {
 		linkerVars, linkerOptions = cbuild.LinkerOptions()
+		// Libraries of dependency contexts
+		libraries := m.DependencyLibraries(index)
+		inputs := m.LinkInputs(index)
+		for _, library := range libraries {
+			if !slices.Contains(inputs, library) {
+				cbuild.LibraryGlobal = append(cbuild.LibraryGlobal, library)
+			}
+		}
+		if len(libraries) > 0 {
+			dependencyLibraries = "\n\n# Relink on changes of dependency libraries" +
+				CMakeCommand("set_property", []string{"TARGET", "${CONTEXT}", "APPEND", "PROPERTY", "LINK_DEPENDS"}, CMakeQuoteAll(libraries)...)
+		}
 	}
 
 	// Make system includes explicit for compilation database completeness
@@ -90,13 +118,13 @@ func (m *Maker) CreateContextCMakeLists(index int) error {
 	includeGlobal["PUBLIC"] = MergeLanguageCommonIncludes(includeGlobal["PUBLIC"])
 
 	// Global component includes
-	for language, paths := range cbuild.IncludeGlobal {
-		includeGlobal["PUBLIC"][language] = utils.AppendUniquely(includeGlobal["PUBLIC"][language], paths...)
+	for _, language := range sortedmap.AsSortedMap(cbuild.IncludeGlobal) {
+		includeGlobal["PUBLIC"][language.Key] = utils.AppendUniquely(includeGlobal["PUBLIC"][language.Key], language.Value...)
 	}
 
 	// Global user includes
-	for language, paths := range cbuild.UserIncGlobal {
-		includeGlobal["PUBLIC"][language] = utils.AppendUniquely(includeGlobal["PUBLIC"][language], paths...)
+	for _, language := range sortedmap.AsSortedMap(cbuild.UserIncGlobal) {
+		includeGlobal["PUBLIC"][language.Key] = utils.AppendUniquely(includeGlobal["PUBLIC"][language.Key], language.Value...)
 	}
 
 	// Global compile options abstractions
@@ -106,6 +134,19 @@ func (m *Maker) CreateContextCMakeLists(index int) error {
 		globalCompilerAbstractions = "\n\n# Compile Options Abstractions" + cbuild.CMakeTargetCompileOptionsAbstractions("${CONTEXT}", abstractions, cbuild.Languages)
 	}
 
+	// Package export of library contexts
+	var packageExport string
+	if outputType == "lib" && m.Options.ExportPackages {
+		err = cbuild.CMakeCreatePackage(contextDir, outputFile, includeGlobal["PUBLIC"])
+		if err != nil {
+			return err
+		}
+		packageExport = cbuild.CMakePackageExport(outputFile, includeGlobal["PUBLIC"])
+	}
+
+	// Executes running after the context target
+	contextExecutes := m.ExecutesCommands(m.ContextExecutes[cbuild.BuildDescType.Context])
+
 	// Extract Dname and Pname
 	dname, pname := utils.ExtractDnamePname(cbuild.BuildDescType.Device)
 	deviceVars := "\nset(DNAME " + dname + ")"
@@ -122,17 +163,17 @@ include("../roots.cmake")
 set(CONTEXT ` + strings.ReplaceAll(cbuild.BuildDescType.Context, " ", "_") + `)
 set(TARGET ${CONTEXT})` + deviceVars + `
 set(DPACK ` + cbuild.BuildDescType.DevicePack + `)
-set(DPACK_DIR "` + cbuild.AddRootPrefix(cbuild.ContextRoot, cbuild.GetDpackDir()) + `")
-set(OUT_DIR "` + outDir + `")
+set(DPACK_DIR ` + CMakeQuote(cbuild.AddRootPrefix(cbuild.ContextRoot, cbuild.GetDpackDir())) + `)
+set(OUT_DIR ` + CMakeQuote(outDir) + `)
 set(CMAKE_EXPORT_COMPILE_COMMANDS ON)
 set(CMAKE_COMPILE_COMMANDS ${CMAKE_CURRENT_BINARY_DIR}/compile_commands.json)
-set(COMPILE_COMMANDS ${OUT_DIR}/compile_commands.json)` + compileMacros + outputByProducts + linkerVars + `
+set(COMPILE_COMMANDS ${OUT_DIR}/compile_commands.json)` + compileMacros + outputByProducts + linkerVars + cbuild.CMakeSharedComponentsDir() + `
 
-# Processor Options` + cbuild.ProcessorOptions() + `
+# Processor Options` + cbuild.ProcessorOptions() + m.CMakeIncludeHooks(HookBeforeToolchain, cbuild.BuildDescType.Context) + `
 
 # Toolchain config map
 set(COMPILER ` + cbuild.Toolchain + `)
-include("toolchain.cmake")
+include("toolchain.cmake")` + m.CMakeIncludeHooks(HookAfterToolchain, cbuild.BuildDescType.Context) + `
 
 # Setup project
 project(${CONTEXT} LANGUAGES ` + strings.Join(cbuild.Languages, " ") + `)
@@ -152,7 +193,7 @@ add_custom_command(OUTPUT ${COMPILE_COMMANDS}
 
 # Setup context
 ` + cmakeTargetType + `(${CONTEXT})
-set_target_properties(${CONTEXT} PROPERTIES PREFIX "" SUFFIX "` + outputExt + `" OUTPUT_NAME "` + outputName + `")
+set_target_properties(${CONTEXT} PROPERTIES PREFIX "" SUFFIX ` + CMakeQuote(outputExt) + ` OUTPUT_NAME ` + CMakeQuote(outputName) + `)
 set_target_properties(${CONTEXT} PROPERTIES ` + outputDirType + ` ${OUT_DIR})
 add_library(${CONTEXT}_GLOBAL INTERFACE)
 
@@ -166,11 +207,11 @@ add_library(${CONTEXT}_GLOBAL INTERFACE)
 include("groups.cmake")
 include("components.cmake")
 ` + cbuild.CMakeTargetLinkLibrariesGlobal() + `
-` + linkerOptions + customCommands + `
+` + linkerOptions + dependencyLibraries + customCommands + contextExecutes + packageExport + m.CMakeIncludeHooks(HookAfterTarget, cbuild.BuildDescType.Context) + `
 `
 	// Update CMakeLists.txt
 	contextCMakeLists := path.Join(contextDir, "CMakeLists.txt")
-	err = utils.UpdateFile(contextCMakeLists, content)
+	err = m.Writer.WriteFile(contextCMakeLists, content)
 	if err != nil {
 		return err
 	}
@@ -202,13 +243,12 @@ func (c *Cbuild) PreprocessorOptions() (options string, macros string, dependenc
 
 		options += "\nset(CPP_OPTIONS_" + language
 		if len(languageOption) > 0 {
-			options += " \"" + languageOption + "\""
+			options += " " + CMakeQuote(languageOption)
 		}
 		for _, option := range miscOptions {
 			option = c.AdjustRelativePath(option)
 			for _, argument := range splitPreprocessorOption(option) {
-				argument = strings.ReplaceAll(argument, "\"", "\\\"")
-				options += " \"" + argument + "\""
+				options += " " + CMakeQuote(argument)
 			}
 		}
 		options += ")"
@@ -250,16 +290,21 @@ func (m *Maker) CMakeCreateToolchain(index int, contextDir string, inc bool) err
 	toolchainConfig = "${CMSIS_COMPILER_ROOT}/" + filepath.ToSlash(toolchainConfig)
 	var include string
 	if inc {
-		include = "include(\"" + toolchainConfig + "\")\n"
+		include = "include(" + CMakeQuote(toolchainConfig) + ")\n"
+	}
+	registeredToolchain := m.RegisteredToolchains[m.SelectedToolchainVersion[index]]
+	toolchainRoot := "\nset(REGISTERED_TOOLCHAIN_ROOT " + CMakeQuote(registeredToolchain.Path) + ")"
+	if m.Options.Relocatable {
+		// the registration of the build host takes precedence over the one at generation time
+		toolchainRoot = CMakeRoot("REGISTERED_TOOLCHAIN_ROOT", registeredToolchain.EnvVar, RelocatePath(registeredToolchain.Path, m.Roots))
 	}
 	content := `# toolchain.cmake
-
-set(REGISTERED_TOOLCHAIN_ROOT "` + m.RegisteredToolchains[m.SelectedToolchainVersion[index]].Path + `")
+` + toolchainRoot + `
 set(REGISTERED_TOOLCHAIN_VERSION "` + m.SelectedToolchainVersion[index].String() + `")
 ` + include + `
 `
 	filename := path.Join(contextDir, "toolchain.cmake")
-	err := utils.UpdateFile(filename, content)
+	err := m.Writer.WriteFile(filename, content)
 	if err != nil {
 		return err
 	}
@@ -271,7 +316,7 @@ func (c *Cbuild) CMakeCreateGroups(contextDir string) error {
 	abstractions := CompilerAbstractions{c.BuildDescType.Debug, c.BuildDescType.Optimize, c.BuildDescType.Warnings, c.BuildDescType.LanguageC, c.BuildDescType.LanguageCpp}
 	content += c.CMakeCreateGroupRecursively("", c.BuildDescType.Groups, abstractions, c.BuildDescType.DefineAsm, c.BuildDescType.Misc.ASM)
 	filename := path.Join(contextDir, "groups.cmake")
-	err := utils.UpdateFile(filename, content)
+	err := c.Writer.WriteFile(filename, content)
 	if err != nil {
 		return err
 	}
@@ -312,12 +357,13 @@ func (c *Cbuild) CMakeCreateGroupRecursively(parent string, groups []Groups,
 		content += CMakeTargetIncludeDirectories(name, c.MergeIncludes(buildFiles.Include, scope, parentName, group.AddPath, group.AddPathAsm, group.DelPath))
 		// target_compile_definitions
 		content += CMakeTargetCompileDefinitions(name, parentName, scope, group.Define, group.Undefine)
-		group.DefineAsm = utils.AppendDefines(group.DefineAsm, parentDefineAsm)
+		group.DefineAsm = utils.AppendDefines(group.DefineAsm, utils.RemoveDefines(parentDefineAsm, group.Undefine...))
 		// compiler abstractions
 		var libraries []string
 		hasFileAbstractions := HasFileAbstractions(group.Files)
 		groupAbstractions := CompilerAbstractions{group.Debug, group.Optimize, group.Warnings, group.LanguageC, group.LanguageCpp}
 		languages := utils.AppendUniquely(maps.Keys(buildFiles.Source), maps.Keys(buildFiles.Custom)...)
+		slices.Sort(languages)
 		abstractions := InheritCompilerAbstractions(parentAbstractions, groupAbstractions)
 		if !AreAbstractionsEmpty(abstractions, c.Languages) && (!hasFileAbstractions || hasChildren) {
 			if AreAbstractionsEmpty(groupAbstractions, c.Languages) {
@@ -344,26 +390,20 @@ func (c *Cbuild) CMakeCreateGroupRecursively(parent string, groups []Groups,
 			content += c.CMakeTargetLinkLibraries(name, scope, libraries...)
 		}
 		// file level handling
+		customFiles := GroupCustomFiles(group.Files)
 		for _, file := range group.Files {
 			if strings.Contains(file.Category, "source") {
 				if HasFileCustomOptions(file) {
-					// custom file target
-					fileTargetName := name + "_" + ReplaceDelimiters(file.File)
-					c.BuildGroups = append(c.BuildGroups, fileTargetName)
-					content += "\n\n# file " + file.File
-					content += c.CMakeAddLibraryCustomFile(fileTargetName, file)
-					// target_include_directories
-					content += CMakeTargetIncludeDirectories(fileTargetName, c.MergeIncludes(ScopeMap{}, "PUBLIC", name, file.AddPath, file.AddPathAsm, file.DelPath))
-					// target_compile_definitions (except asm)
-					if GetLanguage(file) != "ASM" {
-						content += CMakeTargetCompileDefinitions(fileTargetName, name, "PUBLIC", file.Define, file.Undefine)
+					// custom files target shared by files with identical custom options
+					key := CustomOptionsKey(file)
+					if files, ok := customFiles[key]; ok {
+						delete(customFiles, key)
+						content += c.CMakeCreateCustomFilesTarget(name, files)
 					}
-					// target_compile_options
-					content += c.CMakeTargetCompileOptions(fileTargetName, "PUBLIC", false, Misc{}, []string{}, name)
 				}
 				// asm defines are set in file properties
 				if GetLanguage(file) == "ASM" {
-					file.DefineAsm = utils.AppendDefines(file.DefineAsm, group.DefineAsm)
+					file.DefineAsm = utils.AppendDefines(file.DefineAsm, utils.RemoveDefines(group.DefineAsm, file.Undefine...))
 					file.DefineAsm = utils.AppendDefines(file.Define, file.DefineAsm)
 					content += c.SetFileAsmDefines(file, miscAsm)
 				}
@@ -386,11 +426,38 @@ func (c *Cbuild) CMakeCreateGroupRecursively(parent string, groups []Groups,
 	return content
 }
 
+func (c *Cbuild) CMakeCreateCustomFilesTarget(parent string, files []Files) string {
+	name := parent + "_" + ReplaceDelimiters(files[0].File)
+	c.BuildGroups = append(c.BuildGroups, name)
+	content := "\n\n# file"
+	if len(files) > 1 {
+		content += "s"
+	}
+	for _, file := range files {
+		content += " " + file.File
+	}
+	content += c.CMakeAddLibraryCustomFiles(name, files)
+	// target_include_directories
+	content += CMakeTargetIncludeDirectories(name, c.MergeIncludes(ScopeMap{}, "PUBLIC", parent, files[0].AddPath, files[0].AddPathAsm, files[0].DelPath))
+	// target_compile_definitions (except asm)
+	if GetLanguage(files[0]) != "ASM" {
+		content += CMakeTargetCompileDefinitions(name, parent, "PUBLIC", files[0].Define, files[0].Undefine)
+	}
+	// target_compile_options
+	content += c.CMakeTargetCompileOptions(name, "PUBLIC", false, Misc{}, []string{}, parent)
+	return content
+}
+
 func (c *Cbuild) CMakeCreateComponents(contextDir string) error {
 	content := "# components.cmake\n"
 	for _, component := range c.BuildDescType.Components {
 		buildFiles := c.ClassifyFiles(append(component.Files, c.GetAPIFiles(component.Implements)...))
 		name := ReplaceDelimiters(component.Component)
+		// component prebuilt by another context
+		sharedConsumer := c.IsSharedComponentConsumer(name)
+		if sharedConsumer {
+			buildFiles.Interface = true
+		}
 		// default scope
 		scope := "PUBLIC"
 		if buildFiles.Interface {
@@ -398,7 +465,10 @@ func (c *Cbuild) CMakeCreateComponents(contextDir string) error {
 		}
 		// add_library
 		content += "\n# component " + component.Component
-		content += CMakeAddLibrary(name, buildFiles)
+		if sharedConsumer {
+			content += " (shared, built by " + c.SharedComponents[name].Owner + ")"
+		}
+		content += CMakeAddLibrary(name, buildFiles) + c.CMakeSharedComponentLibrary(name)
 		// target_include_directories
 		if len(buildFiles.Include) > 0 {
 			c.IncludeGlobal = AppendGlobalIncludes(c.IncludeGlobal, buildFiles.Include)
@@ -406,12 +476,13 @@ func (c *Cbuild) CMakeCreateComponents(contextDir string) error {
 		content += CMakeTargetIncludeDirectories(name, c.MergeIncludes(buildFiles.Include, scope, "${CONTEXT}", component.AddPath, component.AddPathAsm, component.DelPath))
 		// target_compile_definitions
 		content += CMakeTargetCompileDefinitions(name, "${CONTEXT}", scope, component.Define, component.Undefine)
-		component.DefineAsm = utils.AppendDefines(component.DefineAsm, c.BuildDescType.DefineAsm)
+		component.DefineAsm = utils.AppendDefines(component.DefineAsm, utils.RemoveDefines(c.BuildDescType.DefineAsm, component.Undefine...))
 		// compiler abstractions
 		var libraries []string
 		componentAbstractions := CompilerAbstractions{component.Debug, component.Optimize, component.Warnings, component.LanguageC, component.LanguageCpp}
 		globalAbstractions := CompilerAbstractions{c.BuildDescType.Debug, c.BuildDescType.Optimize, c.BuildDescType.Warnings, c.BuildDescType.LanguageC, c.BuildDescType.LanguageCpp}
 		languages := maps.Keys(buildFiles.Source)
+		slices.Sort(languages)
 		if !AreAbstractionsEmpty(componentAbstractions, languages) {
 			abstractions := InheritCompilerAbstractions(globalAbstractions, componentAbstractions)
 			content += c.CMakeTargetCompileOptionsAbstractions(name, abstractions, languages)
@@ -427,13 +498,16 @@ func (c *Cbuild) CMakeCreateComponents(contextDir string) error {
 		libraries = append(libraries, buildFiles.Library...)
 		c.LibraryGlobal = append(c.LibraryGlobal, buildFiles.Library...)
 		libraries = append(libraries, buildFiles.Object...)
+		if sharedConsumer {
+			libraries = append(libraries, c.FormatWholeArchive([]string{c.SharedComponents[name].Archive})...)
+		}
 		if len(libraries) > 0 {
 			content += c.CMakeTargetLinkLibraries(name, scope, libraries...)
 		}
 		// asm defines are set in file properties
 		for _, file := range component.Files {
 			if strings.Contains(file.Category, "source") && GetLanguage(file) == "ASM" {
-				file.DefineAsm = utils.AppendDefines(file.DefineAsm, component.DefineAsm)
+				file.DefineAsm = utils.AppendDefines(file.DefineAsm, utils.RemoveDefines(component.DefineAsm, file.Undefine...))
 				content += c.SetFileAsmDefines(file, utils.AppendUniquely(c.BuildDescType.Misc.ASM, component.Misc.ASM...))
 			}
 		}
@@ -441,7 +515,7 @@ func (c *Cbuild) CMakeCreateComponents(contextDir string) error {
 	}
 
 	filename := path.Join(contextDir, "components.cmake")
-	err := utils.UpdateFile(filename, content)
+	err := c.Writer.WriteFile(filename, content)
 	if err != nil {
 		return err
 	}
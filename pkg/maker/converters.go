@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker
+
+import (
+	"slices"
+	"strings"
+
+	sortedmap "github.com/gobs/sortedmap"
+	log "github.com/sirupsen/logrus"
+)
+
+// Key of the command templates applying to any toolchain
+const AnyToolchain = "*"
+
+type OutputConverter struct {
+	Type       string              `yaml:"type"`
+	Variable   string              `yaml:"variable"`
+	Comment    string              `yaml:"comment"`
+	Step       string              `yaml:"step"`
+	Inputs     []string            `yaml:"inputs"`
+	Byproducts []string            `yaml:"byproducts"`
+	Commands   map[string][]string `yaml:"commands"`
+}
+
+type OutputConverters map[string]OutputConverter
+
+func DefaultOutputConverters() OutputConverters {
+	return OutputConverters{
+		"hex": {
+			Type:     "hex",
+			Variable: "HEX_FILE",
+			Comment:  "Hex Conversion",
+			Commands: map[string][]string{
+				AnyToolchain: {"${CMAKE_OBJCOPY} ${ELF2HEX}"},
+			},
+		},
+		"bin": {
+			Type:     "bin",
+			Variable: "BIN_FILE",
+			Comment:  "Bin Conversion",
+			Commands: map[string][]string{
+				AnyToolchain: {"${CMAKE_OBJCOPY} ${ELF2BIN}"},
+			},
+		},
+		"map": {
+			Type:     "map",
+			Variable: "LD_MAP_FILE",
+		},
+		"cmse-lib": {
+			Type:     "cmse-lib",
+			Variable: "CMSE_LIB",
+			Comment:  "CMSE Library",
+			Step:     "PRE_LINK",
+			Commands: map[string][]string{
+				AnyToolchain: {"\"\""},
+			},
+		},
+		"srec": {
+			Type:     "srec",
+			Variable: "SREC_FILE",
+			Comment:  "S-Record Conversion",
+			Commands: map[string][]string{
+				"AC6":   {"${CMAKE_OBJCOPY} --m32combined --output \"${OUT_DIR}/${SREC_FILE}\" \"$<TARGET_FILE:${CONTEXT}>\""},
+				"CLANG": {"${CMAKE_OBJCOPY} -O srec \"$<TARGET_FILE:${CONTEXT}>\" \"${OUT_DIR}/${SREC_FILE}\""},
+				"GCC":   {"${CMAKE_OBJCOPY} -O srec \"$<TARGET_FILE:${CONTEXT}>\" \"${OUT_DIR}/${SREC_FILE}\""},
+				"IAR":   {"${CMAKE_OBJCOPY} --silent --srec \"$<TARGET_FILE:${CONTEXT}>\" \"${OUT_DIR}/${SREC_FILE}\""},
+			},
+		},
+		"elf-stripped": {
+			Type:       "elf-stripped",
+			Variable:   "STRIPPED_FILE",
+			Comment:    "Stripped ELF and separate debug file",
+			Byproducts: []string{"${OUT_DIR}/${STRIPPED_FILE}.debug"},
+			Commands: map[string][]string{
+				"CLANG": {
+					"${CMAKE_OBJCOPY} --only-keep-debug \"$<TARGET_FILE:${CONTEXT}>\" \"${OUT_DIR}/${STRIPPED_FILE}.debug\"",
+					"${CMAKE_OBJCOPY} --strip-debug --add-gnu-debuglink=\"${OUT_DIR}/${STRIPPED_FILE}.debug\" \"$<TARGET_FILE:${CONTEXT}>\" \"${OUT_DIR}/${STRIPPED_FILE}\"",
+				},
+				"GCC": {
+					"${CMAKE_OBJCOPY} --only-keep-debug \"$<TARGET_FILE:${CONTEXT}>\" \"${OUT_DIR}/${STRIPPED_FILE}.debug\"",
+					"${CMAKE_OBJCOPY} --strip-debug --add-gnu-debuglink=\"${OUT_DIR}/${STRIPPED_FILE}.debug\" \"$<TARGET_FILE:${CONTEXT}>\" \"${OUT_DIR}/${STRIPPED_FILE}\"",
+				},
+			},
+		},
+	}
+}
+
+// Register adds custom converters, overriding built-in ones with the same type
+func (converters OutputConverters) Register(custom []OutputConverter) {
+	for _, converter := range custom {
+		if len(converter.Type) == 0 {
+			log.Warn("output converter without type was ignored")
+			continue
+		}
+		if len(converter.Variable) == 0 {
+			converter.Variable = strings.ToUpper(ReplaceSpecialChars(converter.Type)) + "_FILE"
+		}
+		if len(converter.Comment) == 0 {
+			converter.Comment = "Output conversion: " + converter.Type
+		}
+		if _, ok := converters[converter.Type]; ok {
+			log.Debug("output converter '" + converter.Type + "' overrides built-in definition")
+		}
+		converters[converter.Type] = converter
+	}
+}
+
+// GetCommands returns the command templates for the given toolchain and whether it is supported
+func (converter OutputConverter) GetCommands(toolchain string) ([]string, bool) {
+	if commands, ok := converter.Commands[toolchain]; ok {
+		return commands, true
+	}
+	commands, ok := converter.Commands[AnyToolchain]
+	return commands, ok
+}
+
+func (converter OutputConverter) SupportedToolchains() []string {
+	var toolchains []string
+	for _, toolchain := range sortedmap.AsSortedMap(converter.Commands) {
+		toolchains = append(toolchains, toolchain.Key)
+	}
+	return toolchains
+}
+
+func (converter OutputConverter) CMakeCustomCommand(commands []string) string {
+	step := converter.Step
+	if len(step) == 0 {
+		step = "POST_BUILD"
+	}
+	content := "\n\n# " + converter.Comment + "\nadd_custom_command(TARGET ${CONTEXT} " + step
+	for _, command := range commands {
+		content += " COMMAND " + command
+	}
+	byproducts := converter.Byproducts
+	if !slices.Contains(byproducts, "${OUT_DIR}/${"+converter.Variable+"}") {
+		byproducts = append([]string{"${OUT_DIR}/${" + converter.Variable + "}"}, byproducts...)
+	}
+	content += " BYPRODUCTS " + strings.Join(byproducts, " ") + ")"
+	// relink and thus rerun the conversion when additional inputs change
+	if len(converter.Inputs) > 0 {
+		content += "\nset_property(TARGET ${CONTEXT} APPEND PROPERTY LINK_DEPENDS " + strings.Join(converter.Inputs, " ") + ")"
+	}
+	return content
+}
+
+func OutputFiles(outputList []Output, toolchain string, converters OutputConverters) (outputByProducts string, outputFile string, outputType string, customCommands string) {
+	for _, output := range outputList {
+		switch output.Type {
+		case "elf", "lib":
+			outputFile = output.File
+			outputType = output.Type
+			continue
+		}
+		converter, ok := converters[output.Type]
+		if !ok {
+			log.Debug("no output converter registered for type '" + output.Type + "'")
+			continue
+		}
+		outputByProducts += "\nset(" + converter.Variable + " \"" + output.File + "\")"
+		if len(converter.Commands) == 0 {
+			continue
+		}
+		commands, supported := converter.GetCommands(toolchain)
+		if !supported {
+			log.Warn("output type '" + output.Type + "' is not supported by toolchain " + toolchain +
+				", supported: " + strings.Join(converter.SupportedToolchains(), ", "))
+			continue
+		}
+		customCommands += converter.CMakeCustomCommand(commands)
+	}
+	return outputByProducts, outputFile, outputType, customCommands
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConverters(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("test default output converters", func(t *testing.T) {
+		output := []maker.Output{
+			{File: "project.elf", Type: "elf"},
+			{File: "project.hex", Type: "hex"},
+			{File: "project.srec", Type: "srec"},
+			{File: "project_s.elf", Type: "elf-stripped"},
+			{File: "project_CMSE_Lib.o", Type: "cmse-lib"},
+		}
+		outputByProducts, outputFile, outputType, customCommands := maker.OutputFiles(output, "GCC", maker.DefaultOutputConverters())
+		assert.Equal("project.elf", outputFile)
+		assert.Equal("elf", outputType)
+		assert.Equal("\nset(HEX_FILE \"project.hex\")\nset(SREC_FILE \"project.srec\")\nset(STRIPPED_FILE \"project_s.elf\")\nset(CMSE_LIB \"project_CMSE_Lib.o\")", outputByProducts)
+		assert.Contains(customCommands, "\n\n# Hex Conversion\nadd_custom_command(TARGET ${CONTEXT} POST_BUILD COMMAND ${CMAKE_OBJCOPY} ${ELF2HEX} BYPRODUCTS ${OUT_DIR}/${HEX_FILE})")
+		assert.Contains(customCommands, "COMMAND ${CMAKE_OBJCOPY} -O srec \"$<TARGET_FILE:${CONTEXT}>\" \"${OUT_DIR}/${SREC_FILE}\" BYPRODUCTS ${OUT_DIR}/${SREC_FILE})")
+		assert.Contains(customCommands, "BYPRODUCTS ${OUT_DIR}/${STRIPPED_FILE} ${OUT_DIR}/${STRIPPED_FILE}.debug)")
+		assert.Contains(customCommands, "\n\n# CMSE Library\nadd_custom_command(TARGET ${CONTEXT} PRE_LINK COMMAND \"\" BYPRODUCTS ${OUT_DIR}/${CMSE_LIB})")
+	})
+
+	t.Run("test unsupported toolchain", func(t *testing.T) {
+		output := []maker.Output{
+			{File: "project.out", Type: "elf"},
+			{File: "project_s.out", Type: "elf-stripped"},
+			{File: "project.unknown", Type: "unknown"},
+		}
+		outputByProducts, _, _, customCommands := maker.OutputFiles(output, "IAR", maker.DefaultOutputConverters())
+		assert.Equal("\nset(STRIPPED_FILE \"project_s.out\")", outputByProducts)
+		assert.Empty(customCommands)
+		assert.Equal([]string{"CLANG", "GCC"}, maker.DefaultOutputConverters()["elf-stripped"].SupportedToolchains())
+	})
+
+	t.Run("test register custom output converters", func(t *testing.T) {
+		var m maker.Maker
+		config, err := m.ParseProjectConfigFile(testRoot + "/run/generic/cbuild2cmake.yml")
+		assert.Nil(err)
+		converters := maker.DefaultOutputConverters()
+		converters.Register(config.OutputConverters)
+		assert.Equal("UF2_FILE", converters["uf2"].Variable)
+
+		output := []maker.Output{
+			{File: "project.elf", Type: "elf"},
+			{File: "project.uf2", Type: "uf2"},
+			{File: "project.hex", Type: "hex"},
+		}
+		outputByProducts, _, _, customCommands := maker.OutputFiles(output, "GCC", converters)
+		assert.Equal("\nset(UF2_FILE \"project.uf2\")\nset(HEX_FILE \"project.hex\")", outputByProducts)
+		assert.Contains(customCommands, "\n\n# UF2 Conversion\nadd_custom_command(TARGET ${CONTEXT} POST_BUILD COMMAND python3 \"${SOLUTION_ROOT}/scripts/uf2conv.py\" \"$<TARGET_FILE:${CONTEXT}>\" -o \"${OUT_DIR}/${UF2_FILE}\" BYPRODUCTS ${OUT_DIR}/${UF2_FILE})")
+		assert.Contains(customCommands, "\nset_property(TARGET ${CONTEXT} APPEND PROPERTY LINK_DEPENDS ${SOLUTION_ROOT}/scripts/uf2conv.py)")
+		assert.Contains(customCommands, "COMMAND ${CMAKE_OBJCOPY} ${ELF2HEX} COMMAND srec_cat")
+
+		// overridden hex converter only supports GCC
+		_, _, _, customCommands = maker.OutputFiles(output, "AC6", converters)
+		assert.NotContains(customCommands, "ELF2HEX")
+	})
+}
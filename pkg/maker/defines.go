@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker
+
+import (
+	"errors"
+	"maps"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type definition struct {
+	macro  string
+	origin string
+}
+
+// definitions maps the macro names visible at a level to their definition
+type definitions map[string]definition
+
+type defineValidator struct {
+	context  string
+	issues   []string
+	warnings []string
+}
+
+// ValidateDefines checks macro names and values at all levels and reports macros
+// redefined with a different value between context, group, component and file
+func (m *Maker) ValidateDefines() error {
+	var issues []string
+	for _, cbuild := range m.Cbuilds {
+		v := defineValidator{context: cbuild.BuildDescType.Context}
+		context := v.check(nil, "context", cbuild.BuildDescType.Define, nil)
+		contextAsm := v.check(nil, "context", cbuild.BuildDescType.DefineAsm, nil)
+		v.check(nil, "linker", cbuild.BuildDescType.Linker.Define, nil)
+		v.checkGroups(context, contextAsm, cbuild.BuildDescType.Groups)
+		for _, component := range cbuild.BuildDescType.Components {
+			origin := "component '" + component.Component + "'"
+			defines := v.check(context, origin, component.Define, component.Undefine)
+			definesAsm := v.check(contextAsm, origin, component.DefineAsm, component.Undefine)
+			v.checkFiles(defines, definesAsm, component.Files)
+		}
+		for _, warning := range v.warnings {
+			log.Warn(warning)
+		}
+		issues = append(issues, v.issues...)
+	}
+	if len(issues) > 0 {
+		return errors.New("invalid defines:\n  " + strings.Join(issues, "\n  "))
+	}
+	return nil
+}
+
+func (v *defineValidator) checkGroups(parent definitions, parentAsm definitions, groups []Groups) {
+	for _, group := range groups {
+		origin := "group '" + group.Group + "'"
+		defines := v.check(parent, origin, group.Define, group.Undefine)
+		definesAsm := v.check(parentAsm, origin, group.DefineAsm, group.Undefine)
+		v.checkFiles(defines, definesAsm, group.Files)
+		v.checkGroups(defines, definesAsm, group.Groups)
+	}
+}
+
+func (v *defineValidator) checkFiles(parent definitions, parentAsm definitions, files []Files) {
+	for _, file := range files {
+		origin := "file '" + file.File + "'"
+		v.check(parent, origin, file.Define, file.Undefine)
+		v.check(parentAsm, origin, file.DefineAsm, file.Undefine)
+	}
+}
+
+// check returns the definitions visible at a level inheriting from its parent
+func (v *defineValidator) check(parent definitions, origin string, defines []interface{}, undefine []string) definitions {
+	current := maps.Clone(parent)
+	if current == nil {
+		current = make(definitions)
+	}
+	for _, name := range undefine {
+		delete(current, name)
+	}
+	for _, define := range defines {
+		def, err := utils.ParseDefine(define)
+		if err != nil {
+			v.issues = append(v.issues, v.context+": "+origin+": "+err.Error())
+			continue
+		}
+		name, _, _ := strings.Cut(def.Name, "(")
+		if previous, ok := current[name]; ok && previous.macro != def.String() {
+			v.warnings = append(v.warnings, v.context+": macro '"+name+"' defined as '"+previous.macro+"' in "+
+				previous.origin+" is redefined as '"+def.String()+"' in "+origin)
+		}
+		current[name] = definition{macro: def.String(), origin: origin}
+	}
+	return current
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefines(t *testing.T) {
+	assert := assert.New(t)
+
+	newMaker := func() maker.Maker {
+		var m maker.Maker
+		m.Cbuilds = []maker.Cbuild{{}}
+		build := &m.Cbuilds[0].BuildDescType
+		build.Context = "project.Debug+ARMCM0"
+		build.Define = maker.Defines{"DEBUG", map[string]interface{}{"LEVEL": 1}}
+		build.Groups = []maker.Groups{{
+			Group:  "Source",
+			Define: maker.Defines{map[string]interface{}{"LEVEL": 1}},
+			Groups: []maker.Groups{{
+				Group:    "Sub",
+				Undefine: []string{"DEBUG"},
+				Define:   maker.Defines{map[string]interface{}{"DEBUG": 0}},
+				Files:    []maker.Files{{File: "sub.c", Define: maker.Defines{"DEBUG_LEVEL"}}},
+			}},
+		}}
+		build.Components = []maker.Components{{
+			Component: "ARM::CMSIS:CORE",
+			Files:     []maker.Files{{File: "core.c", Undefine: []string{"LEVEL"}, Define: maker.Defines{map[string]interface{}{"LEVEL": 2}}}},
+		}}
+		return m
+	}
+
+	captureWarnings := func(validate func()) string {
+		var buffer bytes.Buffer
+		output := log.StandardLogger().Out
+		log.SetOutput(&buffer)
+		defer log.SetOutput(output)
+		validate()
+		return buffer.String()
+	}
+
+	t.Run("test valid defines", func(t *testing.T) {
+		m := newMaker()
+		warnings := captureWarnings(func() { assert.Nil(m.ValidateDefines()) })
+		assert.Empty(warnings)
+	})
+
+	t.Run("test conflicting redefinitions", func(t *testing.T) {
+		m := newMaker()
+		build := &m.Cbuilds[0].BuildDescType
+		build.Groups[0].Define = maker.Defines{map[string]interface{}{"LEVEL": 3}}
+		build.Components[0].Files[0].Undefine = nil
+		build.DefineAsm = maker.Defines{"ASM", "ASM"}
+		warnings := captureWarnings(func() { assert.Nil(m.ValidateDefines()) })
+		assert.Contains(warnings, "macro 'LEVEL' defined as 'LEVEL=1' in context is redefined as 'LEVEL=3' in group 'Source'")
+		assert.Contains(warnings, "macro 'LEVEL' defined as 'LEVEL=1' in context is redefined as 'LEVEL=2' in file 'core.c'")
+		assert.NotContains(warnings, "'ASM'")
+		assert.NotContains(warnings, "'DEBUG'")
+	})
+
+	t.Run("test invalid defines", func(t *testing.T) {
+		m := newMaker()
+		build := &m.Cbuilds[0].BuildDescType
+		build.Groups[0].Groups[0].Files[0].Define = maker.Defines{"DEBUG-LEVEL"}
+		build.Components[0].Define = maker.Defines{map[string]interface{}{"LIST": []interface{}{1}}}
+		assert.EqualError(m.ValidateDefines(), "invalid defines:\n"+
+			"  project.Debug+ARMCM0: file 'sub.c': invalid macro name 'DEBUG-LEVEL'\n"+
+			"  project.Debug+ARMCM0: component 'ARM::CMSIS:CORE': invalid value of macro 'LIST': [1]")
+	})
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
+)
+
+type DependencyNode struct {
+	Name      string
+	Execute   bool
+	DependsOn []string
+}
+
+// DependencyGraph lists contexts and executes with their depends-on references in declaration order
+func (m *Maker) DependencyGraph() []DependencyNode {
+	var graph []DependencyNode
+	for _, cbuild := range m.CbuildIndex.BuildIdx.Cbuilds {
+		graph = append(graph, DependencyNode{Name: cbuild.Project + cbuild.Configuration, DependsOn: cbuild.DependsOn})
+	}
+	for _, item := range m.CbuildIndex.BuildIdx.Executes {
+		graph = append(graph, DependencyNode{Name: item.Execute, Execute: true, DependsOn: item.DependsOn})
+	}
+	return graph
+}
+
+// ValidateDependencies reports depends-on references to unknown contexts or executes
+// and dependency cycles
+func (m *Maker) ValidateDependencies() error {
+	graph := m.DependencyGraph()
+	nodes := make(map[string]int)
+	var names []string
+	for index, node := range graph {
+		nodes[node.Name] = index
+		names = append(names, node.Name)
+	}
+
+	var issues []string
+	for _, node := range graph {
+		for _, dependency := range node.DependsOn {
+			if _, ok := nodes[dependency]; ok {
+				continue
+			}
+			issue := "'" + node.Name + "' depends on unknown '" + dependency + "'"
+			if nearest := utils.NearestMatch(dependency, names); len(nearest) > 0 {
+				issue += ", did you mean '" + nearest + "'?"
+			}
+			issues = append(issues, issue)
+		}
+	}
+
+	// depth-first search, nodes on the current path are 'visiting'
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(graph))
+	var path []string
+	var visit func(index int)
+	visit = func(index int) {
+		state[index] = visiting
+		path = append(path, graph[index].Name)
+		for _, dependency := range graph[index].DependsOn {
+			next, ok := nodes[dependency]
+			if !ok {
+				continue
+			}
+			switch state[next] {
+			case visiting:
+				cycle := append(slices.Clone(path[slices.Index(path, dependency):]), dependency)
+				issues = append(issues, "dependency cycle: "+strings.Join(cycle, " -> "))
+			case unvisited:
+				visit(next)
+			}
+		}
+		path = path[:len(path)-1]
+		state[index] = visited
+	}
+	for index := range graph {
+		if state[index] == unvisited {
+			visit(index)
+		}
+	}
+
+	if len(issues) > 0 {
+		return errors.New("invalid dependencies:\n  " + strings.Join(issues, "\n  "))
+	}
+	return nil
+}
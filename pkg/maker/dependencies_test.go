@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDependencies(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("test valid dependencies", func(t *testing.T) {
+		var m maker.Maker
+		m.CbuildIndex.BuildIdx.Cbuilds = []maker.Cbuilds{
+			{Project: "project", Configuration: ".Release+ARMCM0", DependsOn: []string{"Generate"}},
+		}
+		m.CbuildIndex.BuildIdx.Executes = []maker.Executes{
+			{Execute: "Generate"},
+			{Execute: "Archive", DependsOn: []string{"project.Release+ARMCM0"}},
+		}
+		assert.Nil(m.ValidateDependencies())
+		assert.Equal([]maker.DependencyNode{
+			{Name: "project.Release+ARMCM0", DependsOn: []string{"Generate"}},
+			{Name: "Generate", Execute: true},
+			{Name: "Archive", Execute: true, DependsOn: []string{"project.Release+ARMCM0"}},
+		}, m.DependencyGraph())
+	})
+
+	t.Run("test unknown dependencies", func(t *testing.T) {
+		var m maker.Maker
+		m.CbuildIndex.BuildIdx.Cbuilds = []maker.Cbuilds{
+			{Project: "project", Configuration: ".Release+ARMCM0", DependsOn: []string{"project.Relase+ARMCM0", "Unrelated"}},
+		}
+		m.CbuildIndex.BuildIdx.Executes = []maker.Executes{
+			{Execute: "Archive_Artifacts", DependsOn: []string{"archive_artifact"}},
+		}
+		assert.EqualError(m.ValidateDependencies(), "invalid dependencies:\n"+
+			"  'project.Release+ARMCM0' depends on unknown 'project.Relase+ARMCM0', did you mean 'project.Release+ARMCM0'?\n"+
+			"  'project.Release+ARMCM0' depends on unknown 'Unrelated'\n"+
+			"  'Archive_Artifacts' depends on unknown 'archive_artifact', did you mean 'Archive_Artifacts'?")
+	})
+
+	t.Run("test dependency cycles", func(t *testing.T) {
+		var m maker.Maker
+		m.CbuildIndex.BuildIdx.Cbuilds = []maker.Cbuilds{
+			{Project: "project", Configuration: ".Release+ARMCM0", DependsOn: []string{"Generate"}},
+		}
+		m.CbuildIndex.BuildIdx.Executes = []maker.Executes{
+			{Execute: "Generate", DependsOn: []string{"Sign"}},
+			{Execute: "Sign", DependsOn: []string{"project.Release+ARMCM0"}},
+			{Execute: "Self", DependsOn: []string{"Self"}},
+		}
+		assert.EqualError(m.ValidateDependencies(), "invalid dependencies:\n"+
+			"  dependency cycle: project.Release+ARMCM0 -> Generate -> Sign -> project.Release+ARMCM0\n"+
+			"  dependency cycle: Self -> Self")
+	})
+
+	t.Run("test generation stops on invalid dependencies", func(t *testing.T) {
+		var m maker.Maker
+		m.Params.InputFile = testRoot + "/run/generic/solutionName0.cbuild-idx.yml"
+		err := m.GenerateCMakeLists()
+		assert.ErrorContains(err, "depends on unknown 'projectName.BuildType1+TargetType1.cbuild.yml'")
+		assert.NoFileExists(testRoot + "/run/generic/custom/tmp/path/roots.cmake")
+	})
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker
+
+import (
+	"path"
+	"slices"
+
+	sortedmap "github.com/gobs/sortedmap"
+)
+
+// Script verifying that an execute updated all its declared outputs
+const CheckOutputsScript = "check_outputs.cmake"
+
+func (m *Maker) CMakeCreateCheckOutputsScript() error {
+	content := `# check_outputs.cmake
+# usage: cmake -DEXECUTE=<name> -DINPUT=<inputs> -DOUTPUT=<outputs> -P check_outputs.cmake
+set(MISSING "")
+set(STALE "")
+foreach(FILE IN LISTS OUTPUT)
+  if(NOT EXISTS "${FILE}")
+    list(APPEND MISSING "${FILE}")
+    continue()
+  endif()
+  foreach(DEPENDENCY IN LISTS INPUT)
+    if(EXISTS "${DEPENDENCY}" AND NOT "${FILE}" IS_NEWER_THAN "${DEPENDENCY}")
+      list(APPEND STALE "${FILE}")
+      break()
+    endif()
+  endforeach()
+endforeach()
+if(MISSING OR STALE)
+  set(MESSAGE "execute '${EXECUTE}' did not update its declared outputs:")
+  foreach(FILE IN LISTS MISSING)
+    string(APPEND MESSAGE "\n  missing: ${FILE}")
+  endforeach()
+  foreach(FILE IN LISTS STALE)
+    string(APPEND MESSAGE "\n  older than inputs: ${FILE}")
+  endforeach()
+  message(FATAL_ERROR "${MESSAGE}")
+endif()
+`
+	return m.Writer.WriteFile(path.Join(m.SolutionTmpDir, CheckOutputsScript), content)
+}
+
+// CheckOutputsCommand returns the command verifying the declared outputs of an execute in strict mode
+func (m *Maker) CheckOutputsCommand(item Executes) string {
+	if !m.Options.StrictExecutes || len(item.Output) == 0 {
+		return ""
+	}
+	// quoted arguments keep the lists of several inputs and outputs in one definition
+	command := "\n  COMMAND ${CMAKE_COMMAND} " + CMakeQuote("-DEXECUTE="+item.Execute)
+	if len(item.Input) > 0 {
+		command += " \"-DINPUT=${INPUT}\""
+	}
+	script := "${CMAKE_CURRENT_SOURCE_DIR}/" + CheckOutputsScript
+	if m.IsContextExecute(item.Execute) {
+		script = "${CMAKE_CURRENT_SOURCE_DIR}/../" + CheckOutputsScript
+	}
+	command += " \"-DOUTPUT=${OUTPUT}\" -P " + CMakeQuote(script)
+	return command
+}
+
+// ExecuteRunCommand returns the run command of an execute, forwarding its environment variables through 'cmake -E env'
+func (m *Maker) ExecuteRunCommand(item Executes) string {
+	command := "\n  COMMAND "
+	if len(item.Env) > 0 {
+		command += "${CMAKE_COMMAND} -E env"
+		for _, variable := range sortedmap.AsSortedMap(item.Env) {
+			command += " " + CMakeQuote(variable.Key+"="+variable.Value)
+		}
+		command += " "
+	}
+	return command + QuoteArguments(item.Run)
+}
+
+func (m *Maker) ExecuteWorkingDirectory(item Executes) string {
+	if len(item.WorkingDir) == 0 {
+		return ""
+	}
+	return "\n  WORKING_DIRECTORY " + CMakeQuote(m.AddRootPrefix(m.CbuildIndex.RelDir, item.WorkingDir))
+}
+
+// ExecuteDepfile returns the depfile written by the execute listing its implicit dependencies
+func (m *Maker) ExecuteDepfile(item Executes) string {
+	if len(item.Depfile) == 0 {
+		return ""
+	}
+	return "\n  DEPFILE " + CMakeQuote(m.AddRootPrefix(m.CbuildIndex.RelDir, item.Depfile))
+}
+
+// ProcessContextExecutes moves executes depending on a single context into the context project,
+// where they run after the context target with access to the context and toolchain variables
+func (m *Maker) ProcessContextExecutes() {
+	m.ContextExecutes = make(map[string][]Executes)
+	var executes []Executes
+	for _, item := range m.CbuildIndex.BuildIdx.Executes {
+		if item.Always != nil || len(item.DependsOn) != 1 || !m.IsContextBuilt(item.DependsOn[0]) || m.IsDependency(item.Execute) {
+			executes = append(executes, item)
+			continue
+		}
+		m.ContextExecutes[item.DependsOn[0]] = append(m.ContextExecutes[item.DependsOn[0]], item)
+	}
+	m.CbuildIndex.BuildIdx.Executes = executes
+}
+
+func (m *Maker) IsContextBuilt(context string) bool {
+	for _, cbuild := range m.Cbuilds {
+		if cbuild.BuildDescType.Context == context {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDependency checks whether any context or execute depends on the given name
+func (m *Maker) IsDependency(name string) bool {
+	for _, node := range m.DependencyGraph() {
+		if slices.Contains(node.DependsOn, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Maker) IsContextExecute(execute string) bool {
+	for _, executes := range m.ContextExecutes {
+		for _, item := range executes {
+			if item.Execute == execute {
+				return true
+			}
+		}
+	}
+	return false
+}
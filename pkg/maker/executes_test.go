@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExecutes(t *testing.T) {
+	assert := assert.New(t)
+
+	executes := []maker.Executes{
+		{Execute: "Sign", Run: "sign ${INPUT} ${OUTPUT}", Input: []string{"app.axf"}, Output: []string{"app.signed"}},
+		{Execute: "Version", Run: "version ${OUTPUT}", Output: []string{"version.h"}, Always: map[string]interface{}{}},
+		{Execute: "Notify", Run: "notify"},
+	}
+
+	t.Run("test strict executes", func(t *testing.T) {
+		var m maker.Maker
+		m.Options.StrictExecutes = true
+		content := m.ExecutesCommands(executes)
+		assert.Contains(content, `
+  COMMAND sign "${INPUT}" "${OUTPUT}"
+  COMMAND ${CMAKE_COMMAND} "-DEXECUTE=Sign" "-DINPUT=${INPUT}" "-DOUTPUT=${OUTPUT}" -P "${CMAKE_CURRENT_SOURCE_DIR}/check_outputs.cmake"
+  USES_TERMINAL`)
+		assert.Contains(content, `
+  COMMAND version "${OUTPUT}"
+  COMMAND ${CMAKE_COMMAND} "-DEXECUTE=Version" "-DOUTPUT=${OUTPUT}" -P "${CMAKE_CURRENT_SOURCE_DIR}/check_outputs.cmake"
+  BYPRODUCTS ${OUTPUT}`)
+		assert.NotContains(content, `"-DEXECUTE=Notify"`)
+	})
+
+	t.Run("test strict executes with several outputs", func(t *testing.T) {
+		var m maker.Maker
+		m.Options.StrictExecutes = true
+		content := m.ExecutesCommands([]maker.Executes{
+			{Execute: "Split", Run: "split ${INPUT}", Input: []string{"app.axf", "layout.txt"}, Output: []string{"app.hex", "app.bin"}},
+		})
+		assert.Contains(content, `
+set(INPUT
+  ${SOLUTION_ROOT}/app.axf
+  ${SOLUTION_ROOT}/layout.txt
+)`)
+		assert.Contains(content, `
+set(OUTPUT
+  ${SOLUTION_ROOT}/app.hex
+  ${SOLUTION_ROOT}/app.bin
+)`)
+		assert.Contains(content, `
+  COMMAND ${CMAKE_COMMAND} "-DEXECUTE=Split" "-DINPUT=${INPUT}" "-DOUTPUT=${OUTPUT}" -P "${CMAKE_CURRENT_SOURCE_DIR}/check_outputs.cmake"`)
+	})
+
+	t.Run("test executes without strict mode", func(t *testing.T) {
+		var m maker.Maker
+		assert.NotContains(m.ExecutesCommands(executes), maker.CheckOutputsScript)
+	})
+
+	t.Run("test environment, working directory and depfile", func(t *testing.T) {
+		var m maker.Maker
+		m.SolutionRoot = "/solution"
+		m.CbuildIndex.RelDir = "build"
+		content := m.ExecutesCommands([]maker.Executes{
+			{Execute: "Generate", Run: "generate ${OUTPUT}", Output: []string{"gen.c"}, WorkingDir: "../templates",
+				Env: map[string]string{"TEMPLATES": "${SOLUTION_ROOT}/templates", "MODE": "release"}, Depfile: "gen.d"},
+			{Execute: "Notify", Run: "notify", WorkingDir: "/tmp"},
+		})
+		assert.Contains(content, `
+add_custom_command(OUTPUT ${OUTPUT}
+  COMMAND ${CMAKE_COMMAND} -E cmake_echo_color --green "Executing: Generate"
+  COMMAND ${CMAKE_COMMAND} -E env "MODE=release" "TEMPLATES=${SOLUTION_ROOT}/templates" generate "${OUTPUT}"
+  DEPFILE "${SOLUTION_ROOT}/build/gen.d"
+  WORKING_DIRECTORY "${SOLUTION_ROOT}/templates"
+  USES_TERMINAL
+)`)
+		assert.Contains(content, `
+  COMMAND ${CMAKE_COMMAND} -E touch "${CMAKE_CURRENT_BINARY_DIR}/Notify.stamp"
+  COMMAND notify
+  WORKING_DIRECTORY "/tmp"`)
+	})
+
+	t.Run("test parse execute options", func(t *testing.T) {
+		var item maker.Executes
+		err := yaml.Unmarshal([]byte("execute: Generate\nworking-dir: templates\nenv:\n  MODE: release\ndepfile: gen.d\n"), &item)
+		assert.Nil(err)
+		assert.Nil(item.Always)
+		assert.Equal(maker.Executes{Execute: "Generate", WorkingDir: "templates", Env: map[string]string{"MODE": "release"}, Depfile: "gen.d"}, item)
+	})
+
+	t.Run("test context executes", func(t *testing.T) {
+		var m maker.Maker
+		m.Options.StrictExecutes = true
+		m.Cbuilds = make([]maker.Cbuild, 2)
+		m.Cbuilds[0].BuildDescType.Context = "app.Debug+Board"
+		m.Cbuilds[1].BuildDescType.Context = "boot.Debug+Board"
+		m.CbuildIndex.BuildIdx.Cbuilds = []maker.Cbuilds{
+			{Project: "app", Configuration: ".Debug+Board"},
+			{Project: "boot", Configuration: ".Debug+Board", DependsOn: []string{"Sign_App"}},
+		}
+		m.CbuildIndex.BuildIdx.Executes = []maker.Executes{
+			{Execute: "Hex_App", Run: "${CMAKE_OBJCOPY} -O ihex ${OUT_DIR}/app.elf ${OUTPUT}", Output: []string{"app.hex"}, DependsOn: []string{"app.Debug+Board"}},
+			{Execute: "Notify_App", Run: "notify", Input: []string{"notify.cfg"}, DependsOn: []string{"app.Debug+Board"}},
+			{Execute: "Sign_App", Run: "sign", DependsOn: []string{"app.Debug+Board"}},
+			{Execute: "Archive", Run: "archive", DependsOn: []string{"app.Debug+Board", "boot.Debug+Board"}},
+			{Execute: "Always", Run: "always", Always: map[string]interface{}{}, DependsOn: []string{"app.Debug+Board"}},
+			{Execute: "Unknown", Run: "unknown", DependsOn: []string{"test.Debug+Board"}},
+		}
+		m.ProcessContextExecutes()
+
+		var names []string
+		for _, item := range m.CbuildIndex.BuildIdx.Executes {
+			names = append(names, item.Execute)
+		}
+		assert.Equal([]string{"Sign_App", "Archive", "Always", "Unknown"}, names)
+		assert.True(m.IsContextExecute("Hex_App"))
+		assert.False(m.IsContextExecute("Sign_App"))
+
+		content := m.ExecutesCommands(m.ContextExecutes["app.Debug+Board"])
+		assert.Contains(content, `
+add_custom_target(Hex_App ALL DEPENDS ${OUTPUT})
+add_custom_command(OUTPUT ${OUTPUT} DEPENDS ${CONTEXT}
+  COMMAND ${CMAKE_COMMAND} -E cmake_echo_color --green "Executing: Hex_App"
+  COMMAND ${CMAKE_OBJCOPY} -O ihex ${OUT_DIR}/app.elf "${OUTPUT}"
+  COMMAND ${CMAKE_COMMAND} "-DEXECUTE=Hex_App" "-DOUTPUT=${OUTPUT}" -P "${CMAKE_CURRENT_SOURCE_DIR}/../check_outputs.cmake"`)
+		assert.Contains(content, "add_custom_command(OUTPUT ${OUTPUT} DEPENDS ${INPUT} ${CONTEXT}\n")
+	})
+
+	t.Run("test check outputs script", func(t *testing.T) {
+		var m maker.Maker
+		m.SolutionTmpDir = t.TempDir()
+		assert.Nil(m.CMakeCreateCheckOutputsScript())
+		content, err := utils.ReadFileContent(m.SolutionTmpDir + "/" + maker.CheckOutputsScript)
+		assert.Nil(err)
+		assert.Contains(content, "message(FATAL_ERROR \"${MESSAGE}\")")
+	})
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	GraphContext  = "context"
+	GraphExecute  = "execute"
+	GraphPostStep = "post-build"
+	GraphFile     = "file"
+)
+
+const (
+	EdgeDependsOn = "depends-on"
+	EdgeRunAlways = "run-always"
+	EdgePostBuild = "post-build"
+	EdgeInput     = "input"
+	EdgeOutput    = "output"
+)
+
+type GraphNode struct {
+	Name string
+	Kind string
+}
+
+// GraphEdge points from the step that runs first to the step that depends on it
+type GraphEdge struct {
+	From string
+	To   string
+	Kind string
+}
+
+type BuildGraph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+func (g *BuildGraph) addNode(name string, kind string) {
+	for _, node := range g.Nodes {
+		if node.Name == name {
+			return
+		}
+	}
+	g.Nodes = append(g.Nodes, GraphNode{Name: name, Kind: kind})
+}
+
+func (g *BuildGraph) addEdge(from string, to string, kind string) {
+	edge := GraphEdge{From: from, To: to, Kind: kind}
+	if !slices.Contains(g.Edges, edge) {
+		g.Edges = append(g.Edges, edge)
+	}
+}
+
+func (g *BuildGraph) nodeId(name string) string {
+	for index, node := range g.Nodes {
+		if node.Name == name {
+			return "n" + strconv.Itoa(index)
+		}
+	}
+	return ""
+}
+
+// SolutionBuildGraph collects the build steps and their dependencies as generated in the super CMakeLists
+func (m *Maker) SolutionBuildGraph() BuildGraph {
+	var graph BuildGraph
+	var steps []string
+	for _, cbuild := range m.CbuildIndex.BuildIdx.Cbuilds {
+		context := cbuild.Project + cbuild.Configuration
+		if !slices.Contains(m.Contexts, context) {
+			continue
+		}
+		graph.addNode(context, GraphContext)
+		steps = append(steps, context)
+	}
+	for _, item := range m.CbuildIndex.BuildIdx.Executes {
+		graph.addNode(item.Execute, GraphExecute)
+		steps = append(steps, item.Execute)
+	}
+
+	// depends-on and injected run-always dependencies
+	var postBuildDependencies = make(DependenciesMap)
+	for _, cbuild := range m.CbuildIndex.BuildIdx.Cbuilds {
+		if slices.Contains(steps, cbuild.Project+cbuild.Configuration) {
+			m.addStepEdges(&graph, cbuild.Project+cbuild.Configuration, cbuild.DependsOn)
+		}
+	}
+	for _, item := range m.CbuildIndex.BuildIdx.Executes {
+		m.addStepEdges(&graph, item.Execute, item.DependsOn)
+		postBuildDependencies = m.GetContextDependencies(item.Execute, item.DependsOn, postBuildDependencies)
+	}
+
+	// executes attached to ${CONTEXT}-executes targets
+	for _, context := range m.Contexts {
+		dependencies, ok := postBuildDependencies[context]
+		if !ok {
+			continue
+		}
+		step := context + "-executes"
+		graph.addNode(step, GraphPostStep)
+		for _, dependency := range dependencies {
+			graph.addEdge(dependency, step, EdgePostBuild)
+		}
+		for _, dependency := range m.GetIndependentRunAlways(step) {
+			graph.addEdge(dependency, step, EdgeRunAlways)
+		}
+	}
+
+	// input and output files of executes
+	for _, item := range m.CbuildIndex.BuildIdx.Executes {
+		for _, input := range item.Input {
+			graph.addNode(input, GraphFile)
+			graph.addEdge(input, item.Execute, EdgeInput)
+		}
+		for _, output := range item.Output {
+			graph.addNode(output, GraphFile)
+			graph.addEdge(item.Execute, output, EdgeOutput)
+		}
+	}
+	return graph
+}
+
+func (m *Maker) addStepEdges(graph *BuildGraph, name string, dependsOn []string) {
+	for _, dependency := range dependsOn {
+		if graph.nodeId(dependency) != "" {
+			graph.addEdge(dependency, name, EdgeDependsOn)
+		}
+	}
+	for _, dependency := range m.GetIndependentRunAlways(name) {
+		if dependency != name {
+			graph.addEdge(dependency, name, EdgeRunAlways)
+		}
+	}
+}
+
+// Dot renders the build graph in Graphviz DOT language
+func (g *BuildGraph) Dot() string {
+	shapes := map[string]string{
+		GraphContext:  "box",
+		GraphExecute:  "ellipse",
+		GraphPostStep: "box, style=rounded",
+		GraphFile:     "note, color=gray40, fontcolor=gray40",
+	}
+	styles := map[string]string{
+		EdgeDependsOn: "",
+		EdgeRunAlways: " [style=dashed, label=\"always\"]",
+		EdgePostBuild: " [style=bold, label=\"post-build\"]",
+		EdgeInput:     " [color=gray40]",
+		EdgeOutput:    " [color=gray40]",
+	}
+	escape := strings.NewReplacer("\\", "\\\\", "\"", "\\\"")
+	content := "digraph build {\n  rankdir=LR\n  node [fontname=\"Helvetica\"]"
+	for index, node := range g.Nodes {
+		content += "\n  n" + strconv.Itoa(index) + " [label=\"" + escape.Replace(node.Name) + "\", shape=" + shapes[node.Kind] + "]"
+	}
+	for _, edge := range g.Edges {
+		content += "\n  " + g.nodeId(edge.From) + " -> " + g.nodeId(edge.To) + styles[edge.Kind]
+	}
+	content += "\n}\n"
+	return content
+}
+
+// Mermaid renders the build graph as Mermaid flowchart
+func (g *BuildGraph) Mermaid() string {
+	shapes := map[string][2]string{
+		GraphContext:  {"[", "]"},
+		GraphExecute:  {"(", ")"},
+		GraphPostStep: {"[[", "]]"},
+		GraphFile:     {"[/", "/]"},
+	}
+	arrows := map[string]string{
+		EdgeDependsOn: " --> ",
+		EdgeRunAlways: " -. always .-> ",
+		EdgePostBuild: " == post-build ==> ",
+		EdgeInput:     " --> ",
+		EdgeOutput:    " --> ",
+	}
+	escape := strings.NewReplacer("\"", "#quot;")
+	content := "flowchart LR"
+	for index, node := range g.Nodes {
+		shape := shapes[node.Kind]
+		content += "\n  n" + strconv.Itoa(index) + shape[0] + "\"" + escape.Replace(node.Name) + "\"" + shape[1]
+	}
+	for _, edge := range g.Edges {
+		content += "\n  " + g.nodeId(edge.From) + arrows[edge.Kind] + g.nodeId(edge.To)
+	}
+	content += "\n"
+	return content
+}
+
+// GenerateGraph parses the cbuild files and renders the solution build graph in the given format
+func (m *Maker) GenerateGraph(format string) (string, error) {
+	if format != "dot" && format != "mermaid" {
+		return "", errors.New("unsupported graph format '" + format + "', use dot or mermaid")
+	}
+	if err := m.ParseSolution(); err != nil {
+		return "", err
+	}
+	if err := m.ValidateDependencies(); err != nil {
+		return "", err
+	}
+	graph := m.SolutionBuildGraph()
+	if format == "mermaid" {
+		return graph.Mermaid(), nil
+	}
+	return graph.Dot(), nil
+}
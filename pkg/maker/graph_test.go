@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph(t *testing.T) {
+	assert := assert.New(t)
+
+	var m maker.Maker
+	m.Contexts = []string{"project.Debug+Board"}
+	m.CbuildIndex.BuildIdx.Cbuilds = []maker.Cbuilds{{Project: "project", Configuration: ".Debug+Board"}}
+	m.CbuildIndex.BuildIdx.Executes = []maker.Executes{
+		{Execute: "Sign", Input: []string{"out/project.axf"}, Output: []string{"out/project.signed"}, DependsOn: []string{"project.Debug+Board"}},
+		{Execute: "Stamp", Always: map[string]interface{}{}},
+	}
+
+	t.Run("test solution build graph", func(t *testing.T) {
+		graph := m.SolutionBuildGraph()
+		assert.Equal([]maker.GraphNode{
+			{Name: "project.Debug+Board", Kind: maker.GraphContext},
+			{Name: "Sign", Kind: maker.GraphExecute},
+			{Name: "Stamp", Kind: maker.GraphExecute},
+			{Name: "project.Debug+Board-executes", Kind: maker.GraphPostStep},
+			{Name: "out/project.axf", Kind: maker.GraphFile},
+			{Name: "out/project.signed", Kind: maker.GraphFile},
+		}, graph.Nodes)
+		assert.Equal([]maker.GraphEdge{
+			{From: "Stamp", To: "project.Debug+Board", Kind: maker.EdgeRunAlways},
+			{From: "project.Debug+Board", To: "Sign", Kind: maker.EdgeDependsOn},
+			{From: "Stamp", To: "Sign", Kind: maker.EdgeRunAlways},
+			{From: "Sign", To: "project.Debug+Board-executes", Kind: maker.EdgePostBuild},
+			{From: "Stamp", To: "project.Debug+Board-executes", Kind: maker.EdgeRunAlways},
+			{From: "out/project.axf", To: "Sign", Kind: maker.EdgeInput},
+			{From: "Sign", To: "out/project.signed", Kind: maker.EdgeOutput},
+		}, graph.Edges)
+	})
+
+	t.Run("test dot and mermaid output", func(t *testing.T) {
+		graph := maker.BuildGraph{
+			Nodes: []maker.GraphNode{{Name: "app\"1\"", Kind: maker.GraphContext}, {Name: "Sign", Kind: maker.GraphExecute}},
+			Edges: []maker.GraphEdge{{From: "app\"1\"", To: "Sign", Kind: maker.EdgeDependsOn}},
+		}
+		assert.Equal("digraph build {\n  rankdir=LR\n  node [fontname=\"Helvetica\"]\n"+
+			"  n0 [label=\"app\\\"1\\\"\", shape=box]\n  n1 [label=\"Sign\", shape=ellipse]\n  n0 -> n1\n}\n", graph.Dot())
+		assert.Equal("flowchart LR\n  n0[\"app#quot;1#quot;\"]\n  n1(\"Sign\")\n  n0 --> n1\n", graph.Mermaid())
+	})
+
+	t.Run("test unsupported graph format", func(t *testing.T) {
+		_, err := m.GenerateGraph("svg")
+		assert.EqualError(err, "unsupported graph format 'svg', use dot or mermaid")
+	})
+
+	t.Run("test graph stops on dependency cycles", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Nil(os.WriteFile(path.Join(dir, "project.Debug+ARMCM0.cbuild.yml"),
+			[]byte("build:\n  context: project.Debug+ARMCM0\n  compiler: GCC\n"), 0644))
+		assert.Nil(os.WriteFile(path.Join(dir, "solution.cbuild-idx.yml"), []byte(`build-idx:
+  csolution: solution.csolution.yml
+  cbuilds:
+    - cbuild: project.Debug+ARMCM0.cbuild.yml
+      project: project
+      configuration: .Debug+ARMCM0
+  executes:
+    - execute: Loop_A
+      depends-on:
+        - Loop_B
+    - execute: Loop_B
+      depends-on:
+        - Loop_A
+`), 0644))
+		var m maker.Maker
+		m.Params.InputFile = path.Join(dir, "solution.cbuild-idx.yml")
+		_, err := m.GenerateGraph("dot")
+		assert.ErrorContains(err, "dependency cycle: Loop_A -> Loop_B -> Loop_A")
+	})
+}
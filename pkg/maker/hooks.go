@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker
+
+import (
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Injection points of user CMake fragments:
+//   - before-toolchain: context CMakeLists before the toolchain config is included
+//   - after-toolchain: context CMakeLists after the toolchain config is included, before project()
+//   - after-target: context CMakeLists after the context target, groups and components are defined
+//   - solution-end: end of the super CMakeLists
+const (
+	HookBeforeToolchain = "before-toolchain"
+	HookAfterToolchain  = "after-toolchain"
+	HookAfterTarget     = "after-target"
+	HookSolutionEnd     = "solution-end"
+)
+
+var HookPoints = []string{HookBeforeToolchain, HookAfterToolchain, HookAfterTarget, HookSolutionEnd}
+
+// Hook is a user CMake fragment included at an injection point, the file is relative
+// to the solution root and context hooks are filtered by an optional context pattern
+type Hook struct {
+	File    string `yaml:"file"`
+	Point   string `yaml:"point"`
+	Context string `yaml:"context"`
+}
+
+// ValidateHooks checks the injection points, context patterns and files of the configured hooks
+func (m *Maker) ValidateHooks() error {
+	var issues []string
+	for _, hook := range m.ProjectConfig.Hooks {
+		if !slices.Contains(HookPoints, hook.Point) {
+			issues = append(issues, "hook '"+hook.File+"' has unknown point '"+hook.Point+"', supported are "+strings.Join(HookPoints, ", "))
+		}
+		if _, err := path.Match(hook.Context, ""); err != nil {
+			issues = append(issues, "hook '"+hook.File+"' has invalid context pattern '"+hook.Context+"'")
+		}
+		if hook.Point == HookSolutionEnd && len(hook.Context) > 0 {
+			issues = append(issues, "hook '"+hook.File+"' at "+HookSolutionEnd+" does not apply to a context")
+		}
+		file := hook.File
+		if !filepath.IsAbs(file) {
+			file = path.Join(m.SolutionRoot, file)
+		}
+		if _, err := os.Stat(file); err != nil {
+			issues = append(issues, "hook file '"+hook.File+"' was not found")
+		}
+	}
+	if len(issues) > 0 {
+		return errors.New("invalid hooks:\n  " + strings.Join(issues, "\n  "))
+	}
+	return nil
+}
+
+// CMakeIncludeHooks returns the includes of the hooks at the given point, the context
+// is empty for the super CMakeLists
+func (m *Maker) CMakeIncludeHooks(point string, context string) string {
+	var content string
+	for _, hook := range m.ProjectConfig.Hooks {
+		if hook.Point != point {
+			continue
+		}
+		if len(hook.Context) > 0 {
+			if matched, _ := path.Match(hook.Context, context); !matched {
+				continue
+			}
+		}
+		content += "\ninclude(" + CMakeQuote(m.AddRootPrefix("", hook.File)) + ")"
+	}
+	if len(content) > 0 {
+		content = "\n\n# User hooks " + point + content
+	}
+	return content
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHooks(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("GCC_TOOLCHAIN_12_3_0", testRoot+"/run/path/to/gcc1230/bin")
+
+	t.Run("test include hooks", func(t *testing.T) {
+		var m maker.Maker
+		m.SolutionRoot = "/work/solution"
+		m.ProjectConfig.Hooks = []maker.Hook{
+			{File: "cmake/warnings.cmake", Point: maker.HookAfterTarget},
+			{File: "cmake/debug.cmake", Point: maker.HookAfterTarget, Context: "project.Debug+*"},
+			{File: "cmake/extra.cmake", Point: maker.HookSolutionEnd},
+		}
+		assert.Equal("\n\n# User hooks after-target"+
+			"\ninclude(\"${SOLUTION_ROOT}/cmake/warnings.cmake\")"+
+			"\ninclude(\"${SOLUTION_ROOT}/cmake/debug.cmake\")",
+			m.CMakeIncludeHooks(maker.HookAfterTarget, "project.Debug+ARMCM0"))
+		assert.Equal("\n\n# User hooks after-target\ninclude(\"${SOLUTION_ROOT}/cmake/warnings.cmake\")",
+			m.CMakeIncludeHooks(maker.HookAfterTarget, "project.Release+ARMCM0"))
+		assert.Equal("\n\n# User hooks solution-end\ninclude(\"${SOLUTION_ROOT}/cmake/extra.cmake\")",
+			m.CMakeIncludeHooks(maker.HookSolutionEnd, ""))
+		assert.Empty(m.CMakeIncludeHooks(maker.HookBeforeToolchain, "project.Debug+ARMCM0"))
+	})
+
+	t.Run("test invalid hooks", func(t *testing.T) {
+		var m maker.Maker
+		m.SolutionRoot = t.TempDir()
+		assert.Nil(os.WriteFile(path.Join(m.SolutionRoot, "hook.cmake"), []byte(""), 0644))
+		m.ProjectConfig.Hooks = []maker.Hook{
+			{File: "hook.cmake", Point: maker.HookBeforeToolchain, Context: "*+ARMCM0"},
+			{File: "hook.cmake", Point: "after-project"},
+			{File: "hook.cmake", Point: maker.HookAfterToolchain, Context: "[project"},
+			{File: "hook.cmake", Point: maker.HookSolutionEnd, Context: "*"},
+			{File: "missing.cmake", Point: maker.HookAfterTarget},
+		}
+		assert.EqualError(m.ValidateHooks(), "invalid hooks:\n"+
+			"  hook 'hook.cmake' has unknown point 'after-project', supported are before-toolchain, after-toolchain, after-target, solution-end\n"+
+			"  hook 'hook.cmake' has invalid context pattern '[project'\n"+
+			"  hook 'hook.cmake' at solution-end does not apply to a context\n"+
+			"  hook file 'missing.cmake' was not found")
+	})
+
+	t.Run("test hooks in generated lists", func(t *testing.T) {
+		inputFile := createSyntheticSolution(t, 2, 1)
+		solutionRoot := path.Dir(inputFile)
+		config := "hooks:\n"
+		for _, point := range maker.HookPoints {
+			assert.Nil(os.WriteFile(path.Join(solutionRoot, point+".cmake"), []byte(""), 0644))
+			config += "  - file: " + point + ".cmake\n    point: " + point + "\n"
+		}
+		config += "  - file: after-target.cmake\n    point: after-target\n    context: project.GCC+Target1\n"
+		assert.Nil(os.WriteFile(path.Join(solutionRoot, maker.ProjectConfigFileName), []byte(config), 0644))
+
+		var m maker.Maker
+		m.Params.InputFile = inputFile
+		m.Writer = maker.NewFileWriter()
+		assert.Nil(m.GenerateCMakeLists())
+		for _, context := range []string{"project.GCC+Target0", "project.GCC+Target1"} {
+			content := m.Writer.Files[path.Join(m.SolutionTmpDir, context, "CMakeLists.txt")]
+			beforeToolchain := strings.Index(content, "include(\"${SOLUTION_ROOT}/before-toolchain.cmake\")")
+			toolchain := strings.Index(content, "include(\"toolchain.cmake\")")
+			afterToolchain := strings.Index(content, "include(\"${SOLUTION_ROOT}/after-toolchain.cmake\")")
+			project := strings.Index(content, "project(${CONTEXT}")
+			components := strings.Index(content, "include(\"components.cmake\")")
+			afterTarget := strings.Index(content, "include(\"${SOLUTION_ROOT}/after-target.cmake\")")
+			assert.True(0 < beforeToolchain && beforeToolchain < toolchain && toolchain < afterToolchain &&
+				afterToolchain < project && project < components && components < afterTarget, context)
+			assert.NotContains(content, "solution-end.cmake")
+		}
+		assert.Equal(2, strings.Count(m.Writer.Files[path.Join(m.SolutionTmpDir, "project.GCC+Target1", "CMakeLists.txt")], "after-target.cmake"))
+		superLists := m.Writer.Files[path.Join(m.SolutionTmpDir, "CMakeLists.txt")]
+		assert.True(strings.HasSuffix(superLists, "\n\n# User hooks solution-end\ninclude(\"${SOLUTION_ROOT}/solution-end.cmake\")\n"))
+	})
+
+	t.Run("test generation with missing hook file", func(t *testing.T) {
+		inputFile := createSyntheticSolution(t, 1, 1)
+		config := "hooks:\n  - file: missing.cmake\n    point: after-target\n"
+		assert.Nil(os.WriteFile(path.Join(path.Dir(inputFile), maker.ProjectConfigFileName), []byte(config), 0644))
+		var m maker.Maker
+		m.Params.InputFile = inputFile
+		m.Writer = maker.NewFileWriter()
+		assert.EqualError(m.GenerateCMakeLists(), "invalid hooks:\n  hook file 'missing.cmake' was not found")
+	})
+}
@@ -0,0 +1,203 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker
+
+import (
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type CombinedImage struct {
+	Name    string               `yaml:"name"`
+	Output  string               `yaml:"output"`
+	Formats []string             `yaml:"formats"`
+	Fill    *uint8               `yaml:"fill"`
+	Images  []CombinedImageInput `yaml:"images"`
+}
+
+type CombinedImageInput struct {
+	Context     string `yaml:"context"`
+	File        string `yaml:"file"`
+	LoadAddress string `yaml:"load-address"`
+}
+
+var CombinedImageFormats = []string{"hex", "bin"}
+
+// CombinedImagesCommands creates targets merging context outputs and image files
+// declared in the project configuration
+func (m *Maker) CombinedImagesCommands() (string, error) {
+	if len(m.ProjectConfig.CombinedImages) == 0 {
+		return "", nil
+	}
+	content := "\n\n# Combined images"
+	for _, image := range m.ProjectConfig.CombinedImages {
+		if len(image.Name) == 0 || len(image.Images) == 0 {
+			return "", errors.New("combined image requires a name and a list of images")
+		}
+		output := image.Output
+		if len(output) == 0 {
+			output = path.Join("out", image.Name)
+		}
+		output = m.AddRootPrefix("", output)
+		formats := image.Formats
+		if len(formats) == 0 {
+			formats = []string{"hex"}
+		}
+		var outputs, arguments []string
+		for _, format := range formats {
+			if !slices.Contains(CombinedImageFormats, format) {
+				return "", errors.New("combined image '" + image.Name + "': unsupported format '" + format + "'")
+			}
+			outputs = append(outputs, output+"."+format)
+			arguments = append(arguments, "--format="+format)
+		}
+		report := output + ".layout.txt"
+		outputs = append(outputs, report)
+		arguments = append(arguments, "--output="+output, "--report="+report)
+		if image.Fill != nil {
+			arguments = append(arguments, "--fill="+strconv.Itoa(int(*image.Fill)))
+		}
+
+		var inputs, dependencies []string
+		for _, input := range image.Images {
+			file, dependency, err := m.CombinedImageInputFile(input)
+			if err != nil {
+				return "", errors.New("combined image '" + image.Name + "': " + err.Error())
+			}
+			inputs = append(inputs, file)
+			isHex := strings.EqualFold(path.Ext(file), ".hex")
+			if len(input.LoadAddress) > 0 {
+				if isHex {
+					return "", errors.New("combined image '" + image.Name + "': load address is not supported for " + file +
+						", hex images contain their addresses")
+				}
+				if _, err := strconv.ParseUint(input.LoadAddress, 0, 64); err != nil {
+					return "", errors.New("combined image '" + image.Name + "': invalid load address '" + input.LoadAddress + "'")
+				}
+				file += "@" + input.LoadAddress
+			} else if !isHex {
+				return "", errors.New("combined image '" + image.Name + "': load address is required for " + file)
+			}
+			arguments = append(arguments, file)
+			if len(dependency) > 0 {
+				dependencies = utils.AppendUniquely(dependencies, dependency)
+			}
+		}
+
+		content += "\n\n# Combined image: " + image.Name
+		content += CMakeCombineImagesTarget(image.Name+"-image", outputs, arguments, inputs, dependencies)
+	}
+	return content, nil
+}
+
+// CMakeCbuild2cmakeExecutable locates the cbuild2cmake executable running the combine-images command
+func (m *Maker) CMakeCbuild2cmakeExecutable() string {
+	return "\n\n# Image tools\nfind_program(CBUILD2CMAKE NAMES cbuild2cmake HINTS \"${CMSIS_COMPILER_ROOT}/../bin\" REQUIRED)"
+}
+
+// CMakeCombineImagesTarget creates a target running combine-images, overlapping images fail the build
+func CMakeCombineImagesTarget(name string, outputs []string, arguments []string, inputs []string, dependencies []string) string {
+	content := "\nadd_custom_command(OUTPUT " + strings.Join(CMakeQuoteAll(outputs), " ")
+	content += "\n  COMMAND \"${CBUILD2CMAKE}\" combine-images " + strings.Join(CMakeQuoteAll(arguments), " ")
+	content += "\n  DEPENDS " + strings.Join(CMakeQuoteAll(inputs), " ")
+	content += "\n  VERBATIM\n)"
+	content += "\nadd_custom_target(" + name + " ALL DEPENDS " + strings.Join(CMakeQuoteAll(outputs), " ") + ")"
+	if len(dependencies) > 0 {
+		content += "\nadd_dependencies(" + name + " " + strings.Join(dependencies, " ") + ")"
+	}
+	return content
+}
+
+// CombinedImageInputFile resolves an image input to a file and the target producing it
+func (m *Maker) CombinedImageInputFile(input CombinedImageInput) (file string, dependency string, err error) {
+	if len(input.Context) > 0 {
+		if m.CbuildIndex.BuildIdx.ImageOnly {
+			return "", "", errors.New("context '" + input.Context + "' is not supported in image-only solutions, use a file input")
+		}
+		for index := range m.Cbuilds {
+			if m.Cbuilds[index].BuildDescType.Context != input.Context {
+				continue
+			}
+			hexFile := GetOutputFile(m.Cbuilds[index].BuildDescType.Output, "hex")
+			if len(hexFile) == 0 {
+				return "", "", errors.New("context '" + input.Context + "' has no hex output")
+			}
+			return m.ContextOutputPath(index, hexFile), input.Context + "-build", nil
+		}
+		return "", "", errors.New("context '" + input.Context + "' is not built in this solution")
+	}
+	if len(input.File) == 0 {
+		return "", "", errors.New("image requires a context or a file")
+	}
+	file = m.AddRootPrefix("", input.File)
+	// files generated by executes
+	for _, item := range m.CbuildIndex.BuildIdx.Executes {
+		for _, output := range item.Output {
+			if m.AddRootPrefix(m.CbuildIndex.RelDir, output) == file {
+				dependency = item.Execute
+			}
+		}
+	}
+	return file, dependency, nil
+}
+
+// CombineImages merges images given as <file>[@<load-address>] and writes the requested formats,
+// a '@' is only taken as address separator when the suffix is a number
+func CombineImages(inputs []string, output string, formats []string, report string, fill uint8) error {
+	var images []utils.Image
+	for _, input := range inputs {
+		file := input
+		var loadAddress *uint64
+		if index := strings.LastIndex(input, "@"); index > 0 {
+			if address, err := strconv.ParseUint(input[index+1:], 0, 64); err == nil {
+				file, loadAddress = input[:index], &address
+			}
+		}
+		image, err := utils.ReadImage(file, loadAddress)
+		if err != nil {
+			return err
+		}
+		images = append(images, image)
+	}
+
+	layout := utils.ImageLayoutReport("Image layout of "+path.Base(output), images)
+	segments, err := utils.MergeImages(images)
+	if err != nil {
+		log.Info(layout)
+		return err
+	}
+	_ = os.MkdirAll(filepath.Dir(output), 0755)
+	for _, format := range formats {
+		switch format {
+		case "hex":
+			err = utils.WriteIntelHex(output+".hex", segments)
+		case "bin":
+			err = utils.WriteBinary(output+".bin", segments, fill)
+		default:
+			err = errors.New("unsupported format '" + format + "'")
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if len(report) > 0 {
+		err = os.WriteFile(report, []byte(layout), 0644)
+		if err != nil {
+			return err
+		}
+	}
+	log.Debug(layout)
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImages(t *testing.T) {
+	assert := assert.New(t)
+
+	newMaker := func(images ...maker.CombinedImageInput) maker.Maker {
+		var m maker.Maker
+		m.SolutionRoot = "/solution"
+		m.Cbuilds = make([]maker.Cbuild, 1)
+		m.Cbuilds[0].BaseDir = "/solution/core0"
+		m.Cbuilds[0].BuildDescType.Context = "core0.debug+board"
+		m.Cbuilds[0].BuildDescType.OutputDirs.Outdir = "../out/core0"
+		m.Cbuilds[0].BuildDescType.Output = []maker.Output{{Type: "elf", File: "core0.elf"}, {Type: "hex", File: "core0.hex"}}
+		m.CbuildIndex.BuildIdx.Executes = []maker.Executes{{Execute: "Sign_Boot", Output: []string{"images/boot.bin"}}}
+		m.ProjectConfig.CombinedImages = []maker.CombinedImage{{Name: "firmware", Formats: []string{"hex", "bin"}, Images: images}}
+		return m
+	}
+
+	t.Run("test combined image commands", func(t *testing.T) {
+		m := newMaker(
+			maker.CombinedImageInput{File: "images/boot.bin", LoadAddress: "0x08000000"},
+			maker.CombinedImageInput{Context: "core0.debug+board"},
+		)
+		content, err := m.CombinedImagesCommands()
+		assert.Nil(err)
+		assert.Equal("\n\n# Image tools\nfind_program(CBUILD2CMAKE NAMES cbuild2cmake HINTS \"${CMSIS_COMPILER_ROOT}/../bin\" REQUIRED)",
+			m.CMakeCbuild2cmakeExecutable())
+		assert.Contains(content, `
+
+# Combined image: firmware
+add_custom_command(OUTPUT "${SOLUTION_ROOT}/out/firmware.hex" "${SOLUTION_ROOT}/out/firmware.bin" "${SOLUTION_ROOT}/out/firmware.layout.txt"
+  COMMAND "${CBUILD2CMAKE}" combine-images "--format=hex" "--format=bin" "--output=${SOLUTION_ROOT}/out/firmware" "--report=${SOLUTION_ROOT}/out/firmware.layout.txt" "${SOLUTION_ROOT}/images/boot.bin@0x08000000" "${SOLUTION_ROOT}/out/core0/core0.hex"
+  DEPENDS "${SOLUTION_ROOT}/images/boot.bin" "${SOLUTION_ROOT}/out/core0/core0.hex"
+  VERBATIM
+)
+add_custom_target(firmware-image ALL DEPENDS "${SOLUTION_ROOT}/out/firmware.hex" "${SOLUTION_ROOT}/out/firmware.bin" "${SOLUTION_ROOT}/out/firmware.layout.txt")
+add_dependencies(firmware-image Sign_Boot core0.debug+board-build)`)
+	})
+
+	t.Run("test combined image errors", func(t *testing.T) {
+		m := newMaker(maker.CombinedImageInput{File: "images/boot.bin"})
+		_, err := m.CombinedImagesCommands()
+		assert.EqualError(err, "combined image 'firmware': load address is required for ${SOLUTION_ROOT}/images/boot.bin")
+
+		m = newMaker(maker.CombinedImageInput{Context: "core1.debug+board"})
+		_, err = m.CombinedImagesCommands()
+		assert.EqualError(err, "combined image 'firmware': context 'core1.debug+board' is not built in this solution")
+
+		m = newMaker(maker.CombinedImageInput{Context: "core0.debug+board"})
+		m.Cbuilds[0].BuildDescType.Output = m.Cbuilds[0].BuildDescType.Output[:1]
+		_, err = m.CombinedImagesCommands()
+		assert.EqualError(err, "combined image 'firmware': context 'core0.debug+board' has no hex output")
+
+		m = newMaker(maker.CombinedImageInput{File: "images/app.hex"})
+		m.ProjectConfig.CombinedImages[0].Formats = []string{"srec"}
+		_, err = m.CombinedImagesCommands()
+		assert.EqualError(err, "combined image 'firmware': unsupported format 'srec'")
+
+		m = newMaker(maker.CombinedImageInput{File: "images/app.hex", LoadAddress: "0x08000000"})
+		_, err = m.CombinedImagesCommands()
+		assert.EqualError(err, "combined image 'firmware': load address is not supported for ${SOLUTION_ROOT}/images/app.hex, hex images contain their addresses")
+
+		m = newMaker(maker.CombinedImageInput{Context: "core0.debug+board"})
+		m.CbuildIndex.BuildIdx.ImageOnly = true
+		_, err = m.CombinedImagesCommands()
+		assert.EqualError(err, "combined image 'firmware': context 'core0.debug+board' is not supported in image-only solutions, use a file input")
+	})
+
+	t.Run("test combine images", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Nil(os.WriteFile(path.Join(dir, "app.hex"), []byte(":020000040800F2\n:0400100001020304E2\n:00000001FF\n"), 0644))
+		assert.Nil(os.WriteFile(path.Join(dir, "boot.bin"), []byte{0xAA, 0xBB}, 0644))
+		output := path.Join(dir, "out", "firmware")
+
+		err := maker.CombineImages([]string{path.Join(dir, "boot.bin") + "@0x08000000", path.Join(dir, "app.hex")}, output, []string{"bin"}, output+".layout.txt", 0x00)
+		assert.Nil(err)
+		content, _ := os.ReadFile(output + ".bin")
+		assert.Equal(append([]byte{0xAA, 0xBB}, append(make([]byte, 14), 1, 2, 3, 4)...), content)
+		assert.FileExists(output + ".layout.txt")
+
+		err = maker.CombineImages([]string{path.Join(dir, "boot.bin") + "@0x08000011", path.Join(dir, "app.hex")}, output, []string{"hex"}, "", 0xFF)
+		assert.ErrorContains(err, "boot.bin [0x08000011-0x08000012] overlaps app.hex [0x08000010-0x08000013]")
+
+		err = maker.CombineImages([]string{path.Join(dir, "app.hex") + "@0x08000000"}, output, []string{"hex"}, "", 0xFF)
+		assert.ErrorContains(err, "load address is not supported for hex image")
+	})
+
+	t.Run("test combine images with @ in path", func(t *testing.T) {
+		dir := path.Join(t.TempDir(), "u@corp")
+		assert.Nil(os.MkdirAll(dir, 0755))
+		assert.Nil(os.WriteFile(path.Join(dir, "app.hex"), []byte(":020000040800F2\n:0400100001020304E2\n:00000001FF\n"), 0644))
+		output := path.Join(dir, "firmware")
+		assert.Nil(maker.CombineImages([]string{path.Join(dir, "app.hex")}, output, []string{"hex"}, "", 0xFF))
+		assert.FileExists(output + ".hex")
+	})
+}
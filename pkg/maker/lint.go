@@ -0,0 +1,237 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LintIssue is a semantic problem of the solution that would only surface at build time
+type LintIssue struct {
+	File     string // cbuild file relative to the solution root
+	Line     int    // line of the cbuild file, 0 if unknown
+	Context  string
+	Location string // group, component or node of the cbuild file
+	Message  string
+}
+
+func (i LintIssue) String() string {
+	file := i.File
+	if i.Line > 0 {
+		file += ":" + strconv.Itoa(i.Line)
+	}
+	return file + ": " + i.Context + ": " + i.Location + ": " + i.Message
+}
+
+type linter struct {
+	m         *Maker
+	cbuild    *Cbuild
+	file      string
+	node      *yaml.Node // build node of the cbuild file
+	issues    []LintIssue
+	generated []string
+	sources   map[string]string
+}
+
+// Lint parses the cbuild files and checks them for missing files and directories,
+// sources compiled twice within a context and outputs written by several contexts
+func (m *Maker) Lint() ([]LintIssue, error) {
+	m.UpdateEnvVars()
+	if err := m.ParseSolution(); err != nil {
+		return nil, err
+	}
+	l := linter{m: m}
+
+	// Files that do not exist before the build: outputs of executes and contexts
+	for _, item := range m.CbuildIndex.BuildIdx.Executes {
+		for _, output := range item.Output {
+			l.generated = append(l.generated, l.resolve(m.CbuildIndex.BaseDir, output))
+		}
+	}
+	outputs := make(map[string]string)
+	for index := range m.Cbuilds {
+		cbuild := &m.Cbuilds[index]
+		l.enter(cbuild)
+		for index, output := range cbuild.BuildDescType.Output {
+			file := l.resolve(cbuild.BaseDir, path.Join(cbuild.BuildDescType.OutputDirs.Outdir, output.File))
+			l.generated = append(l.generated, file)
+			if context, ok := outputs[file]; ok && context != cbuild.BuildDescType.Context {
+				node := yamlValue(yamlItem(yamlValue(l.node, "output"), index), "file")
+				l.report("output", "file '"+output.File+"' is also written by context '"+context+"'", node)
+			} else {
+				outputs[file] = cbuild.BuildDescType.Context
+			}
+		}
+	}
+
+	for index := range m.Cbuilds {
+		cbuild := &m.Cbuilds[index]
+		l.enter(cbuild)
+		build := &cbuild.BuildDescType
+		l.checkPaths("context", build.AddPath, "add-path", l.node)
+		l.checkPaths("context", build.AddPathAsm, "add-path-asm", l.node)
+		linker := yamlValue(l.node, "linker")
+		if len(build.Linker.Script) > 0 {
+			l.checkFile("linker", build.Linker.Script, "script", yamlValue(linker, "script"))
+		}
+		if len(build.Linker.Regions) > 0 {
+			l.checkFile("linker", build.Linker.Regions, "regions", yamlValue(linker, "regions"))
+		}
+		l.checkGroups("", build.Groups, yamlValue(l.node, "groups"))
+		components := yamlValue(l.node, "components")
+		for index, component := range build.Components {
+			location := "component '" + component.Component + "'"
+			node := yamlItem(components, index)
+			l.checkPaths(location, component.AddPath, "add-path", node)
+			l.checkPaths(location, component.AddPathAsm, "add-path-asm", node)
+			l.checkFiles(location, component.Files, yamlValue(node, "files"))
+		}
+	}
+	return l.issues, nil
+}
+
+func (l *linter) enter(cbuild *Cbuild) {
+	l.cbuild = cbuild
+	l.file, _ = filepath.Rel(l.m.SolutionRoot, cbuild.CbuildFile)
+	l.file = filepath.ToSlash(l.file)
+	l.sources = make(map[string]string)
+	l.node = nil
+	var document yaml.Node
+	if content, err := os.ReadFile(cbuild.CbuildFile); err == nil && yaml.Unmarshal(content, &document) == nil && len(document.Content) > 0 {
+		l.node = yamlValue(document.Content[0], "build")
+	}
+}
+
+// yamlValue returns the value of a key of a mapping node, nil if the key is not found
+func yamlValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// yamlItem returns an item of a sequence node, nil if the index is out of range
+func yamlItem(node *yaml.Node, index int) *yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode || index >= len(node.Content) {
+		return nil
+	}
+	return node.Content[index]
+}
+
+func (l *linter) report(location string, message string, node *yaml.Node) {
+	var line int
+	if node != nil {
+		line = node.Line
+	}
+	l.issues = append(l.issues, LintIssue{
+		File:     l.file,
+		Line:     line,
+		Context:  l.cbuild.BuildDescType.Context,
+		Location: location,
+		Message:  message,
+	})
+}
+
+// resolve returns the absolute path of a cbuild entry, paths with unknown variables are empty
+func (l *linter) resolve(base string, file string) string {
+	file = strings.NewReplacer("${CMSIS_PACK_ROOT}", l.m.EnvVars.PackRoot, "${CMSIS_COMPILER_ROOT}", l.m.EnvVars.CompilerRoot,
+		"${SOLUTION_ROOT}", l.m.SolutionRoot).Replace(file)
+	if strings.Contains(file, "${") || strings.Contains(file, "$<") {
+		return ""
+	}
+	if !filepath.IsAbs(file) {
+		file = path.Join(base, file)
+	}
+	return path.Clean(filepath.ToSlash(file))
+}
+
+func (l *linter) exists(file string, directory bool) bool {
+	if len(file) == 0 || slices.Contains(l.generated, file) {
+		return true
+	}
+	info, err := os.Stat(file)
+	return err == nil && info.IsDir() == directory
+}
+
+func (l *linter) checkFile(location string, file string, kind string, node *yaml.Node) {
+	if !l.exists(l.resolve(l.cbuild.BaseDir, file), false) {
+		l.report(location, kind+" '"+file+"' does not exist", node)
+	}
+}
+
+// checkPaths checks the paths of the kind key of the given mapping node
+func (l *linter) checkPaths(location string, paths []string, kind string, node *yaml.Node) {
+	for index, dir := range paths {
+		if !l.exists(l.resolve(l.cbuild.BaseDir, dir), true) {
+			l.report(location, kind+" '"+dir+"' does not exist", yamlItem(yamlValue(node, kind), index))
+		}
+	}
+}
+
+func (l *linter) checkGroups(parent string, groups []Groups, nodes *yaml.Node) {
+	for index, group := range groups {
+		node := yamlItem(nodes, index)
+		name := group.Group
+		if len(parent) > 0 {
+			name = parent + "/" + name
+		}
+		location := "group '" + name + "'"
+		l.checkPaths(location, group.AddPath, "add-path", node)
+		l.checkPaths(location, group.AddPathAsm, "add-path-asm", node)
+		l.checkFiles(location, group.Files, yamlValue(node, "files"))
+		l.checkGroups(name, group.Groups, yamlValue(node, "groups"))
+	}
+}
+
+func (l *linter) checkFiles(location string, files []Files, nodes *yaml.Node) {
+	for index, file := range files {
+		fileLocation := location + ": file '" + file.File + "'"
+		node := yamlItem(nodes, index)
+		l.checkPaths(fileLocation, file.AddPath, "add-path", node)
+		l.checkPaths(fileLocation, file.AddPathAsm, "add-path-asm", node)
+		fileNode := yamlValue(node, "file")
+		resolved := l.resolve(l.cbuild.BaseDir, file.File)
+		switch file.Category {
+		case "header", "headerAsm", "headerC", "headerCpp":
+			if len(resolved) > 0 && !l.exists(resolved, false) && !l.exists(path.Dir(resolved), true) {
+				l.report(fileLocation, "directory '"+path.Dir(file.File)+"' does not exist", fileNode)
+			}
+		case "include", "includeAsm", "includeC", "includeCpp":
+			if !l.exists(resolved, true) {
+				l.report(fileLocation, "directory does not exist", fileNode)
+			}
+		case "source", "sourceAsm", "sourceC", "sourceCpp":
+			if !l.exists(resolved, false) {
+				l.report(fileLocation, "file does not exist", fileNode)
+			}
+			if file.Attr == "template" || len(resolved) == 0 {
+				continue
+			}
+			if previous, ok := l.sources[resolved]; ok {
+				l.report(fileLocation, "file is already compiled in "+previous, fileNode)
+			} else {
+				l.sources[resolved] = location
+			}
+		case "library", "object", "linkerScript", "preIncludeLocal", "preIncludeGlobal":
+			if !l.exists(resolved, false) {
+				l.report(fileLocation, "file does not exist", fileNode)
+			}
+		}
+	}
+}
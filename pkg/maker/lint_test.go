@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	assert := assert.New(t)
+
+	createSolution := func(t *testing.T, cbuilds map[string]string, files []string) string {
+		dir := t.TempDir()
+		index := "build-idx:\n  csolution: solution.csolution.yml\n  cbuilds:\n"
+		for _, context := range []string{"Debug", "Release"} {
+			cbuild, ok := cbuilds[context]
+			if !ok {
+				continue
+			}
+			file := "project/project." + context + "+ARMCM0.cbuild.yml"
+			content := "build:\n  context: project." + context + "+ARMCM0\n  compiler: GCC\n" + cbuild
+			assert.Nil(os.MkdirAll(path.Join(dir, "project"), 0755))
+			assert.Nil(os.WriteFile(path.Join(dir, file), []byte(content), 0644))
+			index += "    - cbuild: " + file + "\n      project: project\n      configuration: ." + context + "+ARMCM0\n"
+		}
+		for _, file := range append(files, "solution.csolution.yml") {
+			assert.Nil(os.MkdirAll(path.Dir(path.Join(dir, file)), 0755))
+			assert.Nil(os.WriteFile(path.Join(dir, file), nil, 0644))
+		}
+		assert.Nil(os.WriteFile(path.Join(dir, "solution.cbuild-idx.yml"), []byte(index), 0644))
+		return path.Join(dir, "solution.cbuild-idx.yml")
+	}
+
+	lint := func(inputFile string) []string {
+		var m maker.Maker
+		m.Params.InputFile = inputFile
+		issues, err := m.Lint()
+		assert.Nil(err)
+		var messages []string
+		for _, issue := range issues {
+			messages = append(messages, issue.String())
+		}
+		return messages
+	}
+
+	t.Run("test lint without issues", func(t *testing.T) {
+		inputFile := createSolution(t, map[string]string{"Debug": `  add-path:
+    - inc
+  output-dirs:
+    outdir: ../out/Debug
+  output:
+    - type: elf
+      file: project.elf
+  linker:
+    script: project.ld
+  groups:
+    - group: Source
+      files:
+        - file: main.c
+          category: sourceC
+        - file: inc/main.h
+          category: header
+        - file: template.c
+          category: sourceC
+          attr: template
+        - file: template.c
+          category: sourceC
+          attr: template
+`}, []string{"project/inc/main.h", "project/main.c", "project/template.c", "project/project.ld"})
+		assert.Empty(lint(inputFile))
+	})
+
+	t.Run("test lint issues", func(t *testing.T) {
+		inputFile := createSolution(t, map[string]string{"Debug": `  add-path:
+    - missing
+  output-dirs:
+    outdir: ../out
+  output:
+    - type: elf
+      file: project.elf
+  linker:
+    script: project.ld
+    regions: regions.h
+  groups:
+    - group: Source
+      files:
+        - file: main.c
+          category: sourceC
+      groups:
+        - group: Sub
+          add-path:
+            - sub
+          files:
+            - file: ./main.c
+              category: sourceC
+            - file: missing/main.h
+              category: header
+  components:
+    - component: ARM::CMSIS:CORE
+      files:
+        - file: core.c
+          category: sourceC
+        - file: lib.a
+          category: library
+`, "Release": `  output-dirs:
+    outdir: ../out
+  output:
+    - type: elf
+      file: project.elf
+`}, []string{"project/main.c"})
+		assert.Equal([]string{
+			"project/project.Release+ARMCM0.cbuild.yml:8: project.Release+ARMCM0: output: file 'project.elf' is also written by context 'project.Debug+ARMCM0'",
+			"project/project.Debug+ARMCM0.cbuild.yml:5: project.Debug+ARMCM0: context: add-path 'missing' does not exist",
+			"project/project.Debug+ARMCM0.cbuild.yml:12: project.Debug+ARMCM0: linker: script 'project.ld' does not exist",
+			"project/project.Debug+ARMCM0.cbuild.yml:13: project.Debug+ARMCM0: linker: regions 'regions.h' does not exist",
+			"project/project.Debug+ARMCM0.cbuild.yml:22: project.Debug+ARMCM0: group 'Source/Sub': add-path 'sub' does not exist",
+			"project/project.Debug+ARMCM0.cbuild.yml:24: project.Debug+ARMCM0: group 'Source/Sub': file './main.c': file is already compiled in group 'Source'",
+			"project/project.Debug+ARMCM0.cbuild.yml:26: project.Debug+ARMCM0: group 'Source/Sub': file 'missing/main.h': directory 'missing' does not exist",
+			"project/project.Debug+ARMCM0.cbuild.yml:31: project.Debug+ARMCM0: component 'ARM::CMSIS:CORE': file 'core.c': file does not exist",
+			"project/project.Debug+ARMCM0.cbuild.yml:33: project.Debug+ARMCM0: component 'ARM::CMSIS:CORE': file 'lib.a': file does not exist",
+		}, lint(inputFile))
+	})
+
+	t.Run("test lint generated files", func(t *testing.T) {
+		inputFile := createSolution(t, map[string]string{"Debug": `  output-dirs:
+    outdir: ../out
+  output:
+    - type: lib
+      file: library.a
+`, "Release": `  output-dirs:
+    outdir: ../out/Release
+  output:
+    - type: elf
+      file: project.elf
+  groups:
+    - group: Source
+      files:
+        - file: ../out/library.a
+          category: library
+        - file: ${CMSIS_COMPILER_ROOT}/unknown/${UNKNOWN}.c
+          category: sourceC
+`}, nil)
+		assert.Empty(lint(inputFile))
+	})
+}
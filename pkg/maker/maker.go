@@ -9,6 +9,7 @@ package maker
 import (
 	"path"
 	"path/filepath"
+	"runtime"
 
 	semver "github.com/Masterminds/semver/v3"
 	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
@@ -19,24 +20,41 @@ type Params struct {
 	Options        Options
 	InputFile      string
 	InstallConfigs utils.Configurations
+	Flags          []string // names of the options set on the command line
 }
 
 type Options struct {
-	Quiet         bool
-	Debug         bool
-	Verbose       bool
-	UseContextSet bool
-	Zephyr        bool
+	Quiet            bool
+	Debug            bool
+	Verbose          bool
+	UseContextSet    bool
+	Zephyr           bool
+	SharedComponents bool
+	ExportPackages   bool
+	TrustZoneImage   bool
+	StrictExecutes   bool
+	ContextExecutes  bool
+	Relocatable      bool
+	PrefixMap        bool
+	Incremental      bool
+	Jobs             int
 }
 
 type Vars struct {
 	CbuildIndex              CbuildIndex
 	CbuildSet                CbuildSet
+	ContextExecutes          map[string][]Executes
 	Cbuilds                  []Cbuild
 	Clayers                  []Clayer
 	Contexts                 []string
 	EnvVars                  utils.EnvVars
 	GeneratedFiles           []string
+	OutputConverters         OutputConverters
+	ProjectConfig            ProjectConfig
+	Roots                    []Root
+	SharedComponents         []SharedComponent
+	TrustZonePairs           []TrustZonePair
+	Writer                   *FileWriter
 	ToolchainConfigs         map[*semver.Version]Toolchain
 	RegisteredToolchains     map[*semver.Version]Toolchain
 	SelectedToolchainVersion []*semver.Version
@@ -52,16 +70,33 @@ type Maker struct {
 	Vars
 }
 
-func (m *Maker) GenerateCMakeLists() error {
-	// Update environment variables
+// UpdateEnvVars sets the pack and compiler roots from the environment and the installation
+func (m *Maker) UpdateEnvVars() {
 	m.EnvVars = utils.UpdateEnvVars(m.InstallConfigs.BinPath, m.InstallConfigs.EtcPath)
 	m.EnvVars.PackRoot, _ = filepath.EvalSymlinks(m.EnvVars.PackRoot)
 	m.EnvVars.PackRoot = filepath.ToSlash(m.EnvVars.PackRoot)
 	m.EnvVars.CompilerRoot, _ = filepath.EvalSymlinks(m.EnvVars.CompilerRoot)
 	m.EnvVars.CompilerRoot = filepath.ToSlash(m.EnvVars.CompilerRoot)
+}
+
+func (m *Maker) GenerateCMakeLists() error {
+	// Update environment variables
+	m.UpdateEnvVars()
+
+	// Parse cbuild files and load project configuration
+	err := m.ParseSolution()
+	if err != nil {
+		return err
+	}
 
-	// Parse cbuild files
-	err := m.ParseCbuildFiles()
+	// Validate dependencies before writing any file
+	err = m.ValidateDependencies()
+	if err != nil {
+		return err
+	}
+
+	// Validate defines and report conflicting redefinitions
+	err = m.ValidateDefines()
 	if err != nil {
 		return err
 	}
@@ -83,6 +118,14 @@ func (m *Maker) GenerateCMakeLists() error {
 		return err
 	}
 
+	// Create script verifying declared outputs of executes
+	if m.Options.StrictExecutes && len(m.CbuildIndex.BuildIdx.Executes) > 0 {
+		err = m.CMakeCreateCheckOutputsScript()
+		if err != nil {
+			return err
+		}
+	}
+
 	// Create CMakeLists.txt for image only solution
 	if m.CbuildIndex.BuildIdx.ImageOnly {
 		return m.CreateCMakeListsImageOnly()
@@ -94,6 +137,35 @@ func (m *Maker) GenerateCMakeLists() error {
 		return err
 	}
 
+	// Report toolchains with incomplete prefix map
+	if m.Options.PrefixMap {
+		m.CheckPrefixMap()
+	}
+
+	// Identify TrustZone secure and non-secure pairs
+	m.ProcessTrustZonePairs()
+
+	// Check processor compatibility of linked contexts
+	err = m.CheckLinkCompatibility()
+	if err != nil {
+		return err
+	}
+
+	// Identify components shared across contexts
+	if m.Options.SharedComponents {
+		m.ProcessSharedComponents()
+	}
+
+	// Collect files generated by executes
+	for _, item := range m.CbuildIndex.BuildIdx.Executes {
+		m.GetGeneratedFiles(item.Output)
+	}
+
+	// Move executes depending on a single context into the context
+	if m.Options.ContextExecutes {
+		m.ProcessContextExecutes()
+	}
+
 	// Create super project CMakeLists.txt
 	err = m.CreateSuperCMakeLists()
 	if err != nil {
@@ -101,16 +173,13 @@ func (m *Maker) GenerateCMakeLists() error {
 	}
 
 	// Create context specific CMake files
-	for index := range m.Cbuilds {
-		if m.CbuildIndex.BuildIdx.Cbuilds[index].West {
-			err = m.CreateWestCMakeLists(index)
-		} else {
-			err = m.CreateContextCMakeLists(index)
-		}
-		if err != nil {
-			return err
-		}
-	}
+	return m.CreateContextsCMakeLists()
+}
 
-	return err
+// Workers returns the number of concurrent workers for parsing and generation
+func (m *Maker) Workers() int {
+	if m.Options.Jobs > 0 {
+		return m.Options.Jobs
+	}
+	return runtime.NumCPU()
 }
@@ -1,5 +1,5 @@
 /*
- * Copyright (c) 2024-2025 Arm Limited. All rights reserved.
+ * Copyright (c) 2024-2026 Arm Limited. All rights reserved.
  *
  * SPDX-License-Identifier: Apache-2.0
  */
@@ -7,6 +7,9 @@
 package maker_test
 
 import (
+	"os"
+	"path"
+	"strings"
 	"testing"
 
 	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/inittest"
@@ -58,4 +61,143 @@ func TestMaker(t *testing.T) {
 		err := m.GenerateCMakeLists()
 		assert.Nil(err)
 	})
+
+	t.Run("test context binary dirs from intdir", func(t *testing.T) {
+		var m maker.Maker
+		m.Params.InputFile = testRoot + "/run/solutions/include-define/solution.cbuild-idx.yml"
+		m.Writer = maker.NewFileWriter()
+		err := m.GenerateCMakeLists()
+		assert.Nil(err)
+		content := m.Writer.Files[m.SolutionTmpDir+"/CMakeLists.txt"]
+		assert.Contains(content, `set(BINARY_DIRS
+  "${SOLUTION_ROOT}/tmp/project/ARMCM0/AC6"
+  "${SOLUTION_ROOT}/tmp/project/ARMCM0/CLANG"
+  "${SOLUTION_ROOT}/tmp/project/ARMCM0/GCC"
+  "${SOLUTION_ROOT}/tmp/project/ARMCM0/IAR"
+)`)
+		assert.Contains(content, "BINARY_DIR            ${BINARY_DIR}")
+	})
+
+	t.Run("test context binary dirs fallback to context name", func(t *testing.T) {
+		var m maker.Maker
+		m.SolutionRoot = "/solution"
+		m.SolutionTmpDir = "/solution/tmp"
+		m.Cbuilds = make([]maker.Cbuild, 6)
+		for i, intdir := range []string{"../tmp/project/Debug", "", "../tmp", "..", "../tmp/shared", "../tmp/shared"} {
+			m.Cbuilds[i].BaseDir = "/solution/project"
+			m.Cbuilds[i].BuildDescType.Context = "project.Build" + string(rune('A'+i))
+			m.Cbuilds[i].BuildDescType.OutputDirs.Intdir = intdir
+		}
+		assert.Equal([]string{
+			"${SOLUTION_ROOT}/tmp/project/Debug",
+			"${CMAKE_CURRENT_BINARY_DIR}/build/project.BuildB",
+			"${CMAKE_CURRENT_BINARY_DIR}/build/project.BuildC",
+			"${CMAKE_CURRENT_BINARY_DIR}/build/project.BuildD",
+			"${CMAKE_CURRENT_BINARY_DIR}/build/project.BuildE",
+			"${CMAKE_CURRENT_BINARY_DIR}/build/project.BuildF",
+		}, m.ContextBinaryDirs())
+	})
+
+	t.Run("test context binary dirs independent of the context set", func(t *testing.T) {
+		inputFile := createSyntheticSolution(t, 3, 1)
+		dir := path.Dir(inputFile)
+		cbuildFile := path.Join(dir, "project/project.GCC+Target2.cbuild.yml")
+		content, err := os.ReadFile(cbuildFile)
+		assert.Nil(err)
+		assert.Nil(os.WriteFile(cbuildFile, []byte(strings.Replace(string(content), "intdir: ../tmp/project/ARMCM0/GCC", "intdir: ../tmp/Target2", 1)), 0644))
+		cbuildSet := "cbuild-set:\n  contexts:\n    - context: project.GCC+Target0\n    - context: project.GCC+Target2\n"
+		assert.Nil(os.WriteFile(path.Join(dir, "solution.cbuild-set.yml"), []byte(cbuildSet), 0644))
+
+		binaryDirs := func(useContextSet bool) map[string]string {
+			var m maker.Maker
+			m.Params.InputFile = inputFile
+			m.Params.Options.UseContextSet = useContextSet
+			m.Writer = maker.NewFileWriter()
+			assert.Nil(m.GenerateCMakeLists())
+			dirs := make(map[string]string)
+			for i, binaryDir := range m.ContextBinaryDirs() {
+				dirs[m.Cbuilds[i].BuildDescType.Context] = binaryDir
+			}
+			return dirs
+		}
+		all, selected := binaryDirs(false), binaryDirs(true)
+		assert.Len(selected, 2)
+		for _, context := range []string{"project.GCC+Target0", "project.GCC+Target2"} {
+			assert.Equal(all[context], selected[context])
+		}
+		assert.Equal("${CMAKE_CURRENT_BINARY_DIR}/build/project.GCC+Target0", selected["project.GCC+Target0"])
+		assert.Equal("${SOLUTION_ROOT}/tmp/Target2", selected["project.GCC+Target2"])
+	})
+
+	t.Run("test incremental context builds", func(t *testing.T) {
+		var m maker.Maker
+		m.Params.InputFile = testRoot + "/run/solutions/include-define/solution.cbuild-idx.yml"
+		m.Params.Options.Incremental = true
+		m.Writer = maker.NewFileWriter()
+		err := m.GenerateCMakeLists()
+		assert.Nil(err)
+		content := m.Writer.Files[m.SolutionTmpDir+"/CMakeLists.txt"]
+		assert.Contains(content, "BUILD_ALWAYS          FALSE")
+		assert.Contains(content, `ExternalProject_Add_Step(${CONTEXT} changes
+    COMMAND           ${CMAKE_COMMAND} "-DBINARY_DIR=${BINARY_DIR}" "-DSTAMP=${BINARY_DIR}/changes.stamp" -P "${CMAKE_CURRENT_SOURCE_DIR}/check_changes.cmake"
+    BYPRODUCTS        "${BINARY_DIR}/changes.stamp"
+    ALWAYS            TRUE
+    DEPENDEES         configure database
+  )
+  ExternalProject_Add_StepDependencies(${CONTEXT} build "${BINARY_DIR}/changes.stamp")`)
+		script := m.Writer.Files[m.SolutionTmpDir+"/"+maker.CheckChangesScript]
+		assert.Contains(script, "set(ENV{NINJA_STATUS} \""+maker.CheckChangesStatus+"\")")
+		assert.Contains(script, "COMMAND ${CMAKE_COMMAND} --build \"${BINARY_DIR}\" ${BUILD_ARGS} -- -n")
+		assert.Contains(script, "if(RESULT EQUAL 0 AND NOT OUTPUT MATCHES \""+maker.CheckChangesStatus+"\")")
+		assert.NotContains(script, "no work to do")
+		assert.Contains(script, "file(TOUCH \"${STAMP}\")")
+	})
+
+	t.Run("test incremental context builds with context executes", func(t *testing.T) {
+		inputFile := createSyntheticSolution(t, 1, 1)
+		index, err := os.ReadFile(inputFile)
+		assert.Nil(err)
+		executes := "  executes:\n    - execute: Hex\n      run: ${CMAKE_OBJCOPY} -O ihex ${OUT_DIR}/project.elf ${OUTPUT}\n" +
+			"      output:\n        - out/project.hex\n      depends-on:\n        - project.GCC+Target0\n"
+		assert.Nil(os.WriteFile(inputFile, append(index, executes...), 0644))
+		var m maker.Maker
+		m.Params.InputFile = inputFile
+		m.Params.Options.Incremental = true
+		m.Params.Options.ContextExecutes = true
+		m.Writer = maker.NewFileWriter()
+		assert.Nil(m.GenerateCMakeLists())
+		content := m.Writer.Files[m.SolutionTmpDir+"/CMakeLists.txt"]
+		assert.Contains(content, "COMMAND               ${CMAKE_COMMAND} --build <BINARY_DIR>\n")
+		assert.Contains(content, `"-DSTAMP=${BINARY_DIR}/changes.stamp" -P "${CMAKE_CURRENT_SOURCE_DIR}/check_changes.cmake"`)
+		assert.Contains(content, "DEPENDEES         configure database\n")
+		assert.NotContains(content, "# Execute: Hex")
+		// the context execute is part of the default target built by the build step and the dry run
+		contextLists := m.Writer.Files[m.SolutionTmpDir+"/project.GCC+Target0/CMakeLists.txt"]
+		assert.Contains(contextLists, "add_custom_target(Hex ALL DEPENDS ${OUTPUT})")
+	})
+
+	t.Run("test incremental context builds with west", func(t *testing.T) {
+		var m maker.Maker
+		m.Params.InputFile = testRoot + "/run/solutions/west/solution.cbuild-idx.yml"
+		m.Params.Options.Incremental = true
+		m.Writer = maker.NewFileWriter()
+		err := m.GenerateCMakeLists()
+		assert.Nil(err)
+		content := m.Writer.Files[m.SolutionTmpDir+"/CMakeLists.txt"]
+		assert.Contains(content, `"-DSTAMP=${BINARY_DIR}/changes.stamp" "-DBUILD_ARGS=${WEST_TARGET}" -P`)
+	})
+
+	t.Run("test incremental context builds require ninja", func(t *testing.T) {
+		var m maker.Maker
+		m.Params.InputFile = testRoot + "/run/solutions/include-define/solution.cbuild-idx.yml"
+		m.Params.Options.Incremental = true
+		m.ProjectConfig.Generator = "Unix Makefiles"
+		m.Writer = maker.NewFileWriter()
+		err := m.GenerateCMakeLists()
+		assert.Nil(err)
+		content := m.Writer.Files[m.SolutionTmpDir+"/CMakeLists.txt"]
+		assert.Contains(content, "BUILD_ALWAYS          TRUE")
+		assert.NotContains(content, "changes.stamp")
+		assert.NotContains(m.Writer.Files, m.SolutionTmpDir+"/"+maker.CheckChangesScript)
+	})
 }
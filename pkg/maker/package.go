@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker
+
+import (
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
+	sortedmap "github.com/gobs/sortedmap"
+)
+
+// Directory of the package templates in the context directory
+const PackageDir = "package"
+
+// PackageTargetName returns the imported target name exposed by the package of a library context
+func (c *Cbuild) PackageTargetName(outputName string) string {
+	return strings.ReplaceAll(c.BuildDescType.Context, " ", "_") + "::" + outputName
+}
+
+// Package layout, the package is relocatable and does not refer to the build machine
+const (
+	PackageLibDir     = "lib"
+	PackageIncludeDir = "include"
+	PackageConfigDir  = "lib/cmake"
+)
+
+var packageVariableRegex = regexp.MustCompile(`\$\{(\w+)\}`)
+
+// PackageIncludeDirs returns the public include directories of a library context in the
+// order they are installed into the package, the index is the package include subdirectory
+func PackageIncludeDirs(includes LanguageMap) []string {
+	var dirs []string
+	for _, language := range sortedmap.AsSortedMap(includes) {
+		for _, dir := range language.Value {
+			if !strings.Contains(dir, "$<") {
+				dirs = utils.AppendUniquely(dirs, dir)
+			}
+		}
+	}
+	return dirs
+}
+
+// PackageLibraries returns the library files of the global link libraries installed into the package
+func (c *Cbuild) PackageLibraries() []string {
+	var libraries []string
+	for _, library := range c.LinkLibrariesGlobal() {
+		library = strings.TrimSpace(library)
+		if strings.Contains(library, "/") && !strings.HasPrefix(library, "-") {
+			libraries = utils.AppendUniquely(libraries, library)
+		}
+	}
+	return libraries
+}
+
+// CMakeCreatePackage creates the package templates of a library context, they are configured
+// with @ONLY by the context project so that toolchain variables get resolved while package
+// paths stay relative to the installed package
+func (c *Cbuild) CMakeCreatePackage(contextDir string, outputFile string, includes LanguageMap) error {
+	name := c.PackageTargetName(strings.TrimSuffix(outputFile, path.Ext(outputFile)))
+
+	// Imported library and its usage requirements
+	targets := `# Imported library of context ` + c.BuildDescType.Context + `
+include_guard(GLOBAL)
+
+get_filename_component(PACKAGE_PREFIX "${CMAKE_CURRENT_LIST_DIR}/../../.." ABSOLUTE)
+
+add_library(` + name + ` STATIC IMPORTED)
+set_target_properties(` + name + ` PROPERTIES IMPORTED_LOCATION "${PACKAGE_PREFIX}/` + PackageLibDir + `/` + outputFile + `")
+`
+	if len(includes) > 0 {
+		dirs := PackageIncludeDirs(includes)
+		packageIncludes := make(LanguageMap)
+		for language, languageDirs := range includes {
+			for _, dir := range languageDirs {
+				if index := slices.Index(dirs, dir); index >= 0 {
+					packageIncludes[language] = append(packageIncludes[language], "${PACKAGE_PREFIX}/"+PackageIncludeDir+"/"+strconv.Itoa(index))
+				}
+			}
+		}
+		targets += CMakeTargetIncludeDirectories(name, ScopeMap{"INTERFACE": packageIncludes}) + "\n"
+	}
+	if len(c.BuildDescType.Define) > 0 {
+		targets += CMakeTargetCompileDefinitions(name, "", "INTERFACE", c.BuildDescType.Define, []string{}) + "\n"
+	}
+	if optionsMap := c.ProcessorCompileOptionsMap(); len(optionsMap) > 0 {
+		targets += "\ntarget_compile_options(" + name + " INTERFACE"
+		for _, language := range sortedmap.AsSortedMap(optionsMap) {
+			options := c.LanguageSpecificCompileOptions(language.Key, language.Value...)
+			targets += packageVariableRegex.ReplaceAllString(options, "@$1@")
+		}
+		targets += "\n)\n"
+	}
+	if libraries := c.LinkLibrariesGlobal(); len(libraries) > 0 {
+		packageLibraries := c.PackageLibraries()
+		for index, library := range libraries {
+			if slices.Contains(packageLibraries, strings.TrimSpace(library)) {
+				indentation := library[:len(library)-len(strings.TrimLeft(library, " "))]
+				libraries[index] = indentation + "${PACKAGE_PREFIX}/" + PackageLibDir + "/" + path.Base(strings.TrimSpace(library))
+			}
+		}
+		targets += c.CMakeTargetLinkLibraries(name, "INTERFACE", libraries...) + "\n"
+	}
+
+	// Package entry point
+	config := `# Package of context ` + c.BuildDescType.Context + `
+include("${CMAKE_CURRENT_LIST_DIR}/@CONTEXT@Targets.cmake")
+`
+	err := c.Writer.WriteFile(path.Join(contextDir, PackageDir, "Targets.cmake.in"), targets)
+	if err != nil {
+		return err
+	}
+	return c.Writer.WriteFile(path.Join(contextDir, PackageDir, "Config.cmake.in"), config)
+}
+
+// CMakePackageExport configures the package files and installs the package, it is installed
+// into the output directory after each build and can be installed elsewhere with
+// cmake --install <binary dir> --prefix <dir>
+func (c *Cbuild) CMakePackageExport(outputFile string, includes LanguageMap) string {
+	content := `
+
+# Package export
+configure_file("` + PackageDir + `/Targets.cmake.in" "` + PackageDir + `/${CONTEXT}Targets.cmake" @ONLY)
+configure_file("` + PackageDir + `/Config.cmake.in" "` + PackageDir + `/${CONTEXT}Config.cmake" @ONLY)
+install(FILES "${OUT_DIR}/` + outputFile + `" DESTINATION ` + PackageLibDir + `)`
+	for _, library := range c.PackageLibraries() {
+		content += "\ninstall(FILES " + CMakeQuote(library) + " DESTINATION " + PackageLibDir + ")"
+	}
+	for index, dir := range PackageIncludeDirs(includes) {
+		content += "\ninstall(DIRECTORY " + CMakeQuote(dir+"/") + " DESTINATION " + PackageIncludeDir + "/" + strconv.Itoa(index) + ")"
+	}
+	content += `
+install(FILES
+  "${CMAKE_CURRENT_BINARY_DIR}/` + PackageDir + `/${CONTEXT}Targets.cmake"
+  "${CMAKE_CURRENT_BINARY_DIR}/` + PackageDir + `/${CONTEXT}Config.cmake"
+  DESTINATION ` + PackageConfigDir + `/${CONTEXT}
+)
+add_custom_command(TARGET ${CONTEXT} POST_BUILD
+  COMMAND ${CMAKE_COMMAND} --install "${CMAKE_CURRENT_BINARY_DIR}" --prefix "${OUT_DIR}/` + PackageDir + `"
+)`
+	return content
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackage(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("test package export of library context", func(t *testing.T) {
+		var m maker.Maker
+		m.Params.InputFile = testRoot + "/run/minimal/minimal.cbuild-idx.yml"
+		m.Options.ExportPackages = true
+		err := m.GenerateCMakeLists()
+		assert.Nil(err)
+
+		contextDir := testRoot + "/run/minimal/custom/tmp/path/minimal.AC6+ARMCM0"
+		content, err := utils.ReadFileContent(contextDir + "/CMakeLists.txt")
+		assert.Nil(err)
+		assert.Contains(content, "\n# Package export"+
+			"\nconfigure_file(\"package/Targets.cmake.in\" \"package/${CONTEXT}Targets.cmake\" @ONLY)"+
+			"\nconfigure_file(\"package/Config.cmake.in\" \"package/${CONTEXT}Config.cmake\" @ONLY)"+
+			"\ninstall(FILES \"${OUT_DIR}/minimal.lib\" DESTINATION lib)"+
+			"\ninstall(DIRECTORY \"${SOLUTION_ROOT}/RTE/_AC6_ARMCM0/\" DESTINATION include/0)"+
+			"\ninstall(DIRECTORY \"${CMSIS_PACK_ROOT}/ARM/CMSIS/6.0.0/CMSIS/Core/Include/\" DESTINATION include/1)")
+		assert.Contains(content, "\n  DESTINATION lib/cmake/${CONTEXT}\n)"+
+			"\nadd_custom_command(TARGET ${CONTEXT} POST_BUILD"+
+			"\n  COMMAND ${CMAKE_COMMAND} --install \"${CMAKE_CURRENT_BINARY_DIR}\" --prefix \"${OUT_DIR}/package\"\n)")
+
+		targets, err := utils.ReadFileContent(contextDir + "/package/Targets.cmake.in")
+		assert.Nil(err)
+		// package paths are relative to the installed package
+		assert.Contains(targets, "get_filename_component(PACKAGE_PREFIX \"${CMAKE_CURRENT_LIST_DIR}/../../..\" ABSOLUTE)"+
+			"\n\nadd_library(minimal.AC6+ARMCM0::minimal STATIC IMPORTED)"+
+			"\nset_target_properties(minimal.AC6+ARMCM0::minimal PROPERTIES IMPORTED_LOCATION \"${PACKAGE_PREFIX}/lib/minimal.lib\")")
+		assert.Contains(targets, "\ntarget_include_directories(minimal.AC6+ARMCM0::minimal INTERFACE"+
+			"\n  \"${PACKAGE_PREFIX}/include/0\"\n  \"${PACKAGE_PREFIX}/include/1\"\n  \"${PACKAGE_PREFIX}/include/2\"\n)")
+		assert.NotContains(targets, "${OUT_DIR}")
+		assert.NotContains(targets, "${SOLUTION_ROOT}")
+		assert.NotContains(targets, "${CMSIS_PACK_ROOT}")
+		assert.Contains(targets, "\ntarget_compile_definitions(minimal.AC6+ARMCM0::minimal INTERFACE\n  $<$<COMPILE_LANGUAGE:C,CXX>:\n    ARMCM0\n    _RTE_\n  >\n)")
+		assert.Contains(targets, "\ntarget_compile_options(minimal.AC6+ARMCM0::minimal INTERFACE\n  $<$<COMPILE_LANGUAGE:C>:\n    \"SHELL:@CC_CPU@\"\n    \"SHELL:@CC_FLAGS@\"\n  >")
+		// misc options are not part of the usage requirements
+		assert.NotContains(targets, "-Wno-license-management")
+
+		config, err := utils.ReadFileContent(contextDir + "/package/Config.cmake.in")
+		assert.Nil(err)
+		assert.Equal("# Package of context minimal.AC6+ARMCM0\ninclude(\"${CMAKE_CURRENT_LIST_DIR}/@CONTEXT@Targets.cmake\")\n", config)
+	})
+
+	t.Run("test package libraries", func(t *testing.T) {
+		var cbuild maker.Cbuild
+		cbuild.BuildDescType.Context = "lib.Release+ARMCM0"
+		cbuild.BuildDescType.Misc.Library = []string{"-lm"}
+		cbuild.LibraryGlobal = []string{"${SOLUTION_ROOT}/lib/libext.a"}
+		assert.Equal([]string{"${SOLUTION_ROOT}/lib/libext.a"}, cbuild.PackageLibraries())
+		assert.Equal([]string{"${SOLUTION_ROOT}/inc", "${CMSIS_PACK_ROOT}/inc"}, maker.PackageIncludeDirs(maker.LanguageMap{
+			"ALL": {"${SOLUTION_ROOT}/inc", "$<TARGET_PROPERTY:${CONTEXT},INTERFACE_INCLUDE_DIRECTORIES>"},
+			"C":   {"${SOLUTION_ROOT}/inc", "${CMSIS_PACK_ROOT}/inc"},
+		}))
+		assert.Contains(cbuild.CMakePackageExport("lib.a", nil),
+			"\ninstall(FILES \"${SOLUTION_ROOT}/lib/libext.a\" DESTINATION lib)")
+	})
+
+	t.Run("test no package export without option", func(t *testing.T) {
+		var m maker.Maker
+		m.Params.InputFile = testRoot + "/run/minimal/minimal.cbuild-idx.yml"
+		err := m.GenerateCMakeLists()
+		assert.Nil(err)
+
+		content, err := utils.ReadFileContent(testRoot + "/run/minimal/custom/tmp/path/minimal.AC6+ARMCM0/CMakeLists.txt")
+		assert.Nil(err)
+		assert.NotContains(content, "# Package export")
+	})
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	"github.com/stretchr/testify/assert"
+)
+
+// createSyntheticSolution writes a solution with the given number of contexts and source and header files per context
+func createSyntheticSolution(tb testing.TB, contexts int, files int) string {
+	template, err := os.ReadFile(testRoot + "/run/solutions/build-c/project/project.GCC+ARMCM0.cbuild.yml")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	var sources strings.Builder
+	for file := range files {
+		sources.WriteString("        - file: ./source/file" + strconv.Itoa(file) + ".c\n          category: sourceC\n")
+		sources.WriteString("        - file: ./include/dir" + strconv.Itoa(file%500) + "/file" + strconv.Itoa(file) + ".h\n          category: header\n")
+	}
+	dir := tb.TempDir()
+	index := "build-idx:\n  csolution: solution.csolution.yml\n  cbuilds:\n"
+	for context := range contexts {
+		target := "Target" + strconv.Itoa(context)
+		cbuild := strings.ReplaceAll(string(template), "GCC+ARMCM0", "GCC+"+target)
+		cbuild = strings.Replace(cbuild, "        - file: ./main.c\n          category: sourceC\n", sources.String(), 1)
+		file := "project/project.GCC+" + target + ".cbuild.yml"
+		if err := os.MkdirAll(path.Join(dir, "project"), 0755); err != nil {
+			tb.Fatal(err)
+		}
+		if err := os.WriteFile(path.Join(dir, file), []byte(cbuild), 0644); err != nil {
+			tb.Fatal(err)
+		}
+		index += "    - cbuild: " + file + "\n      project: project\n      configuration: .GCC+" + target + "\n"
+	}
+	if err := os.WriteFile(path.Join(dir, "solution.csolution.yml"), []byte("solution:\n"), 0644); err != nil {
+		tb.Fatal(err)
+	}
+	if err := os.WriteFile(path.Join(dir, "solution.cbuild-idx.yml"), []byte(index), 0644); err != nil {
+		tb.Fatal(err)
+	}
+	return path.Join(dir, "solution.cbuild-idx.yml")
+}
+
+func readTree(root string) map[string]string {
+	tree := make(map[string]string)
+	_ = filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			content, _ := os.ReadFile(file)
+			tree[strings.TrimPrefix(file, root)] = string(content)
+		}
+		return nil
+	})
+	return tree
+}
+
+func TestParallel(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("test parallel generation is identical to sequential", func(t *testing.T) {
+		inputFile := createSyntheticSolution(t, 12, 50)
+		tmpDir := path.Join(path.Dir(inputFile), "tmp")
+
+		var sequential maker.Maker
+		sequential.Params.InputFile = inputFile
+		sequential.Params.Options.Jobs = 1
+		assert.Nil(sequential.GenerateCMakeLists())
+		expected := readTree(tmpDir)
+		assert.Len(expected, 1+1+12*4)
+		assert.Nil(os.RemoveAll(tmpDir))
+
+		var parallel maker.Maker
+		parallel.Params.InputFile = inputFile
+		parallel.Params.Options.Jobs = 8
+		assert.Nil(parallel.GenerateCMakeLists())
+		assert.Equal(sequential.Contexts, parallel.Contexts)
+		assert.Equal(expected, readTree(tmpDir))
+	})
+
+	t.Run("test parallel parsing reports missing cbuild files", func(t *testing.T) {
+		inputFile := createSyntheticSolution(t, 3, 1)
+		assert.Nil(os.Remove(path.Join(path.Dir(inputFile), "project/project.GCC+Target1.cbuild.yml")))
+		var m maker.Maker
+		m.Params.InputFile = inputFile
+		assert.Nil(m.ParseCbuildFiles())
+		assert.Equal([]string{"project.GCC+Target0", "project.GCC+Target2"}, m.Contexts)
+	})
+}
+
+func BenchmarkGenerateCMakeLists(b *testing.B) {
+	inputFile := createSyntheticSolution(b, 32, 2000)
+	for _, jobs := range []int{1, 0} {
+		name := "jobs=" + strconv.Itoa(jobs)
+		if jobs == 0 {
+			name = "jobs=cpus"
+		}
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				m := maker.Maker{Params: maker.Params{InputFile: inputFile, Options: maker.Options{Jobs: jobs, Quiet: true}}}
+				if err := m.GenerateCMakeLists(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkLargeContext(b *testing.B) {
+	inputFile := createSyntheticSolution(b, 1, 20000)
+	for b.Loop() {
+		m := maker.Maker{Params: maker.Params{InputFile: inputFile, Options: maker.Options{Quiet: true}}}
+		if err := m.GenerateCMakeLists(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
 	"gopkg.in/yaml.v3"
 
 	log "github.com/sirupsen/logrus"
@@ -44,41 +45,42 @@ type CbuildSet struct {
 
 type Cbuild struct {
 	BuildDescType struct {
-		GeneratedBy      string        `yaml:"generated-by"`
-		CurrentGenerator struct{}      `yaml:"current-generator"`
-		Solution         string        `yaml:"solution"`
-		Project          string        `yaml:"project"`
-		Context          string        `yaml:"context"`
-		Compiler         string        `yaml:"compiler"`
-		Board            string        `yaml:"board"`
-		BoardPack        string        `yaml:"board-pack"`
-		Device           string        `yaml:"device"`
-		DevicePack       string        `yaml:"device-pack"`
-		Processor        Processor     `yaml:"processor"`
-		Packs            []Packs       `yaml:"packs"`
-		Optimize         string        `yaml:"optimize"`
-		Debug            string        `yaml:"debug"`
-		Warnings         string        `yaml:"warnings"`
-		LanguageC        string        `yaml:"language-C"`
-		LanguageCpp      string        `yaml:"language-CPP"`
-		Lto              bool          `yaml:"link-time-optimize"`
-		Misc             Misc          `yaml:"misc"`
-		Define           []interface{} `yaml:"define"`
-		DefineAsm        []interface{} `yaml:"define-asm"`
-		AddPath          []string      `yaml:"add-path"`
-		AddPathAsm       []string      `yaml:"add-path-asm"`
-		OutputDirs       OutputDirs    `yaml:"output-dirs"`
-		Output           []Output      `yaml:"output"`
-		Components       []Components  `yaml:"components"`
-		Apis             []Apis        `yaml:"apis"`
-		Linker           Linker        `yaml:"linker"`
-		Groups           []Groups      `yaml:"groups"`
-		Generators       []struct{}    `yaml:"generators"`
-		ConstructedFiles []Files       `yaml:"constructed-files"`
-		Licenses         []struct{}    `yaml:"licenses"`
-		West             West          `yaml:"west"`
+		GeneratedBy      string       `yaml:"generated-by"`
+		CurrentGenerator struct{}     `yaml:"current-generator"`
+		Solution         string       `yaml:"solution"`
+		Project          string       `yaml:"project"`
+		Context          string       `yaml:"context"`
+		Compiler         string       `yaml:"compiler"`
+		Board            string       `yaml:"board"`
+		BoardPack        string       `yaml:"board-pack"`
+		Device           string       `yaml:"device"`
+		DevicePack       string       `yaml:"device-pack"`
+		Processor        Processor    `yaml:"processor"`
+		Packs            []Packs      `yaml:"packs"`
+		Optimize         string       `yaml:"optimize"`
+		Debug            string       `yaml:"debug"`
+		Warnings         string       `yaml:"warnings"`
+		LanguageC        string       `yaml:"language-C"`
+		LanguageCpp      string       `yaml:"language-CPP"`
+		Lto              bool         `yaml:"link-time-optimize"`
+		Misc             Misc         `yaml:"misc"`
+		Define           Defines      `yaml:"define"`
+		DefineAsm        Defines      `yaml:"define-asm"`
+		AddPath          []string     `yaml:"add-path"`
+		AddPathAsm       []string     `yaml:"add-path-asm"`
+		OutputDirs       OutputDirs   `yaml:"output-dirs"`
+		Output           []Output     `yaml:"output"`
+		Components       []Components `yaml:"components"`
+		Apis             []Apis       `yaml:"apis"`
+		Linker           Linker       `yaml:"linker"`
+		Groups           []Groups     `yaml:"groups"`
+		Generators       []struct{}   `yaml:"generators"`
+		ConstructedFiles []Files      `yaml:"constructed-files"`
+		Licenses         []struct{}   `yaml:"licenses"`
+		West             West         `yaml:"west"`
 	} `yaml:"build"`
 	BaseDir            string
+	CbuildFile         string
 	ContextRoot        string
 	SolutionRoot       string
 	Languages          []string
@@ -91,13 +93,17 @@ type Cbuild struct {
 	Toolchain          string
 	GeneratedFiles     []string
 	LinkerLto          bool
+	PrefixMap          bool
+	SharedComponents   map[string]*SharedComponent
+	Roots              []Root
+	Writer             *FileWriter
 }
 
 type Clayer struct {
 	Layer struct {
-		Description string        `yaml:"description"`
-		Packs       []Packs       `yaml:"packs"`
-		Define      []interface{} `yaml:"define"`
+		Description string  `yaml:"description"`
+		Packs       []Packs `yaml:"packs"`
+		Define      Defines `yaml:"define"`
 		Components  []struct {
 			Component string `yaml:"component"`
 		} `yaml:"components"`
@@ -136,59 +142,62 @@ type Apis struct {
 }
 
 type Components struct {
-	Component   string        `yaml:"component"`
-	Condition   string        `yaml:"condition"`
-	SelectedBy  string        `yaml:"selected-by"`
-	Implements  string        `yaml:"implements"`
-	Rtedir      string        `yaml:"rtedir"`
-	Optimize    string        `yaml:"optimize"`
-	Debug       string        `yaml:"debug"`
-	Warnings    string        `yaml:"warnings"`
-	LanguageC   string        `yaml:"language-C"`
-	LanguageCpp string        `yaml:"language-CPP"`
-	Lto         bool          `yaml:"link-time-optimize"`
-	Define      []interface{} `yaml:"define"`
-	DefineAsm   []interface{} `yaml:"define-asm"`
-	Undefine    []string      `yaml:"undefine"`
-	AddPath     []string      `yaml:"add-path"`
-	AddPathAsm  []string      `yaml:"add-path-asm"`
-	DelPath     []string      `yaml:"del-path"`
-	Misc        Misc          `yaml:"misc"`
-	Files       []Files       `yaml:"files"`
-	Generator   Generator     `yaml:"generator"`
-	FromPack    string        `yaml:"from-pack"`
+	Component   string    `yaml:"component"`
+	Condition   string    `yaml:"condition"`
+	SelectedBy  string    `yaml:"selected-by"`
+	Implements  string    `yaml:"implements"`
+	Rtedir      string    `yaml:"rtedir"`
+	Optimize    string    `yaml:"optimize"`
+	Debug       string    `yaml:"debug"`
+	Warnings    string    `yaml:"warnings"`
+	LanguageC   string    `yaml:"language-C"`
+	LanguageCpp string    `yaml:"language-CPP"`
+	Lto         bool      `yaml:"link-time-optimize"`
+	Define      Defines   `yaml:"define"`
+	DefineAsm   Defines   `yaml:"define-asm"`
+	Undefine    []string  `yaml:"undefine"`
+	AddPath     []string  `yaml:"add-path"`
+	AddPathAsm  []string  `yaml:"add-path-asm"`
+	DelPath     []string  `yaml:"del-path"`
+	Misc        Misc      `yaml:"misc"`
+	Files       []Files   `yaml:"files"`
+	Generator   Generator `yaml:"generator"`
+	FromPack    string    `yaml:"from-pack"`
 }
 
 type Executes struct {
-	Execute   string                 `yaml:"execute"`
-	Run       string                 `yaml:"run"`
-	Always    map[string]interface{} `yaml:"always,inline"`
-	Input     []string               `yaml:"input"`
-	Output    []string               `yaml:"output"`
-	DependsOn []string               `yaml:"depends-on"`
+	Execute    string                 `yaml:"execute"`
+	Run        string                 `yaml:"run"`
+	Always     map[string]interface{} `yaml:"always,inline"`
+	Input      []string               `yaml:"input"`
+	Output     []string               `yaml:"output"`
+	DependsOn  []string               `yaml:"depends-on"`
+	WorkingDir string                 `yaml:"working-dir"`
+	Env        map[string]string      `yaml:"env"`
+	Depfile    string                 `yaml:"depfile"`
 }
 
 type Files struct {
-	File        string        `yaml:"file"`
-	Category    string        `yaml:"category"`
-	Scope       string        `yaml:"scope"`
-	Language    string        `yaml:"language"`
-	Attr        string        `yaml:"attr"`
-	Version     string        `yaml:"version"`
-	Optimize    string        `yaml:"optimize"`
-	Debug       string        `yaml:"debug"`
-	Warnings    string        `yaml:"warnings"`
-	LanguageC   string        `yaml:"language-C"`
-	LanguageCpp string        `yaml:"language-CPP"`
-	Link        string        `yaml:"link"`
-	Lto         bool          `yaml:"link-time-optimize"`
-	Define      []interface{} `yaml:"define"`
-	DefineAsm   []interface{} `yaml:"define-asm"`
-	Undefine    []string      `yaml:"undefine"`
-	AddPath     []string      `yaml:"add-path"`
-	AddPathAsm  []string      `yaml:"add-path-asm"`
-	DelPath     []string      `yaml:"del-path"`
-	Misc        Misc          `yaml:"misc"`
+	File        string   `yaml:"file"`
+	Category    string   `yaml:"category"`
+	Scope       string   `yaml:"scope"`
+	Language    string   `yaml:"language"`
+	Attr        string   `yaml:"attr"`
+	Version     string   `yaml:"version"`
+	Optimize    string   `yaml:"optimize"`
+	Debug       string   `yaml:"debug"`
+	Warnings    string   `yaml:"warnings"`
+	LanguageC   string   `yaml:"language-C"`
+	LanguageCpp string   `yaml:"language-CPP"`
+	Link        string   `yaml:"link"`
+	Lto         bool     `yaml:"link-time-optimize"`
+	Define      Defines  `yaml:"define"`
+	DefineAsm   Defines  `yaml:"define-asm"`
+	Undefine    []string `yaml:"undefine"`
+	AddPath     []string `yaml:"add-path"`
+	AddPathAsm  []string `yaml:"add-path-asm"`
+	DelPath     []string `yaml:"del-path"`
+	Misc        Misc     `yaml:"misc"`
 }
 
 type Generator struct {
@@ -199,28 +208,54 @@ type Generator struct {
 }
 
 type Groups struct {
-	Group       string        `yaml:"group"`
-	Groups      []Groups      `yaml:"groups"`
-	Files       []Files       `yaml:"files"`
-	Optimize    string        `yaml:"optimize"`
-	Debug       string        `yaml:"debug"`
-	Warnings    string        `yaml:"warnings"`
-	LanguageC   string        `yaml:"language-C"`
-	LanguageCpp string        `yaml:"language-CPP"`
-	Lto         bool          `yaml:"link-time-optimize"`
-	Define      []interface{} `yaml:"define"`
-	DefineAsm   []interface{} `yaml:"define-asm"`
-	Undefine    []string      `yaml:"undefine"`
-	AddPath     []string      `yaml:"add-path"`
-	AddPathAsm  []string      `yaml:"add-path-asm"`
-	DelPath     []string      `yaml:"del-path"`
-	Misc        Misc          `yaml:"misc"`
+	Group       string   `yaml:"group"`
+	Groups      []Groups `yaml:"groups"`
+	Files       []Files  `yaml:"files"`
+	Optimize    string   `yaml:"optimize"`
+	Debug       string   `yaml:"debug"`
+	Warnings    string   `yaml:"warnings"`
+	LanguageC   string   `yaml:"language-C"`
+	LanguageCpp string   `yaml:"language-CPP"`
+	Lto         bool     `yaml:"link-time-optimize"`
+	Define      Defines  `yaml:"define"`
+	DefineAsm   Defines  `yaml:"define-asm"`
+	Undefine    []string `yaml:"undefine"`
+	AddPath     []string `yaml:"add-path"`
+	AddPathAsm  []string `yaml:"add-path-asm"`
+	DelPath     []string `yaml:"del-path"`
+	Misc        Misc     `yaml:"misc"`
+}
+
+// Defines keeps the source text of non-string scalar values, numbers such as
+// 0x10 or 010 are passed to the compiler as written instead of being converted
+type Defines []interface{}
+
+func (d *Defines) UnmarshalYAML(node *yaml.Node) error {
+	var defines []interface{}
+	err := node.Decode(&defines)
+	if err != nil {
+		return err
+	}
+	for index, item := range node.Content {
+		define, ok := defines[index].(map[string]interface{})
+		if !ok || item.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(item.Content); i += 2 {
+			value := item.Content[i+1]
+			if value.Kind == yaml.ScalarNode && value.ShortTag() != "!!str" && value.ShortTag() != "!!null" {
+				define[item.Content[i].Value] = value.Value
+			}
+		}
+	}
+	*d = defines
+	return nil
 }
 
 type Linker struct {
-	Regions string        `yaml:"regions"`
-	Script  string        `yaml:"script"`
-	Define  []interface{} `yaml:"define"`
+	Regions string  `yaml:"regions"`
+	Script  string  `yaml:"script"`
+	Define  Defines `yaml:"define"`
 }
 
 type Misc struct {
@@ -263,12 +298,12 @@ type Packs struct {
 }
 
 type West struct {
-	ProjectId string        `yaml:"project-id"`
-	AppPath   string        `yaml:"app-path"`
-	Board     string        `yaml:"board"`
-	Device    string        `yaml:"device"`
-	WestDefs  []interface{} `yaml:"west-defs"`
-	WestOpt   []string      `yaml:"west-opt"`
+	ProjectId string   `yaml:"project-id"`
+	AppPath   string   `yaml:"app-path"`
+	Board     string   `yaml:"board"`
+	Device    string   `yaml:"device"`
+	WestDefs  Defines  `yaml:"west-defs"`
+	WestOpt   []string `yaml:"west-opt"`
 }
 
 func (m *Maker) ParseCbuildIndexFile(cbuildIndexFile string) (data CbuildIndex, err error) {
@@ -298,8 +333,10 @@ func (m *Maker) ParseCbuildFile(cbuildFile string) (data Cbuild, err error) {
 	return
 }
 
-func (m *Maker) ParseCbuildFiles() error {
-	// Parse cbuild-idx file
+var csolutionPattern = regexp.MustCompile(`(.*)\.csolution.ya?ml`)
+
+// ParseCbuildIndex parses the cbuild-idx file and sets the solution root and name
+func (m *Maker) ParseCbuildIndex() error {
 	cbuildIndex, err := m.ParseCbuildIndexFile(m.Params.InputFile)
 	if err != nil {
 		return err
@@ -311,10 +348,38 @@ func (m *Maker) ParseCbuildFiles() error {
 	m.SolutionRoot, _ = filepath.EvalSymlinks(m.SolutionRoot)
 	m.SolutionRoot = filepath.ToSlash(m.SolutionRoot)
 	m.SolutionName = filepath.Base(m.CbuildIndex.BuildIdx.Csolution)
-	reg := regexp.MustCompile(`(.*)\.csolution.ya?ml`)
-	m.SolutionName = reg.ReplaceAllString(m.SolutionName, "$1")
+	m.SolutionName = csolutionPattern.ReplaceAllString(m.SolutionName, "$1")
 	m.CbuildIndex.RelDir, _ = filepath.Rel(m.SolutionRoot, m.CbuildIndex.BaseDir)
 	m.CbuildIndex.RelDir = filepath.ToSlash(m.CbuildIndex.RelDir)
+	return nil
+}
+
+func (m *Maker) ParseCbuildFiles() error {
+	// Parse cbuild-idx file
+	err := m.ParseCbuildIndex()
+	if err != nil {
+		return err
+	}
+	return m.ParseContextFiles()
+}
+
+// ParseSolution parses the cbuild-idx file, loads the project configuration and parses
+// the cbuild files, options of the configuration affect the parsing of cbuild files
+func (m *Maker) ParseSolution() error {
+	err := m.ParseCbuildIndex()
+	if err != nil {
+		return err
+	}
+	err = m.LoadProjectConfig()
+	if err != nil {
+		return err
+	}
+	return m.ParseContextFiles()
+}
+
+// ParseContextFiles parses the cbuild-set and cbuild files referenced by the parsed cbuild-idx file
+func (m *Maker) ParseContextFiles() error {
+	m.Roots = m.RelocatableRoots()
 
 	// Parse cbuild-set file
 	if m.Options.UseContextSet {
@@ -331,26 +396,42 @@ func (m *Maker) ParseCbuildFiles() error {
 	}
 
 	// Parse cbuild files
+	var cbuildFiles []string
 	for _, cbuildRef := range m.CbuildIndex.BuildIdx.Cbuilds {
 		if m.Options.UseContextSet && !slices.Contains(m.Contexts, cbuildRef.Project+cbuildRef.Configuration) {
 			continue
 		}
-		cbuildFile := path.Join(m.CbuildIndex.BaseDir, cbuildRef.Cbuild)
-		if _, err := os.Stat(cbuildFile); os.IsNotExist(err) {
-			log.Warn("file " + cbuildFile + " was not found")
-			continue
+		cbuildFiles = append(cbuildFiles, path.Join(m.CbuildIndex.BaseDir, cbuildRef.Cbuild))
+	}
+	cbuilds := make([]*Cbuild, len(cbuildFiles))
+	err := utils.ParallelFor(len(cbuildFiles), m.Workers(), func(index int) error {
+		if _, err := os.Stat(cbuildFiles[index]); os.IsNotExist(err) {
+			return nil
 		}
-		cbuild, err := m.ParseCbuildFile(cbuildFile)
+		cbuild, err := m.ParseCbuildFile(cbuildFiles[index])
 		if err != nil {
 			return err
 		}
+		cbuild.BaseDir, _ = filepath.Abs(path.Dir(cbuildFiles[index]))
+		cbuild.BaseDir = filepath.ToSlash(cbuild.BaseDir)
+		cbuild.CbuildFile = cbuildFiles[index]
+		cbuild.SolutionRoot = m.SolutionRoot
+		cbuild.Roots = m.Roots
+		cbuilds[index] = &cbuild
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for index, cbuild := range cbuilds {
+		if cbuild == nil {
+			log.Warn("file " + cbuildFiles[index] + " was not found")
+			continue
+		}
 		if !m.Options.UseContextSet {
 			m.Contexts = append(m.Contexts, cbuild.BuildDescType.Context)
 		}
-		cbuild.BaseDir, _ = filepath.Abs(path.Dir(cbuildFile))
-		cbuild.BaseDir = filepath.ToSlash(cbuild.BaseDir)
-		cbuild.SolutionRoot = m.SolutionRoot
-		m.Cbuilds = append(m.Cbuilds, cbuild)
+		m.Cbuilds = append(m.Cbuilds, *cbuild)
 	}
 	return err
 }
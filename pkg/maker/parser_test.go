@@ -1,5 +1,5 @@
 /*
- * Copyright (c) 2024-2025 Arm Limited. All rights reserved.
+ * Copyright (c) 2024-2026 Arm Limited. All rights reserved.
  *
  * SPDX-License-Identifier: Apache-2.0
  */
@@ -13,6 +13,7 @@ import (
 
 	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func TestParser(t *testing.T) {
@@ -182,4 +183,18 @@ func TestParser(t *testing.T) {
 		assert.Equal("csolution version 2.11.0", data.BuildIdx.GeneratedBy)
 		assert.True(data.BuildIdx.ImageOnly)
 	})
+
+	t.Run("test parsing defines keeps scalar text", func(t *testing.T) {
+		var defines maker.Defines
+		err := yaml.Unmarshal([]byte("- PLAIN\n- HEX: 0x10\n- OCTAL: 010\n- FLAG: true\n- QUOTED: \"0x10\"\n- NONE:\n"), &defines)
+		assert.Nil(err)
+		assert.Equal(maker.Defines{
+			"PLAIN",
+			map[string]interface{}{"HEX": "0x10"},
+			map[string]interface{}{"OCTAL": "010"},
+			map[string]interface{}{"FLAG": "true"},
+			map[string]interface{}{"QUOTED": "0x10"},
+			map[string]interface{}{"NONE": nil},
+		}, defines)
+	})
 }
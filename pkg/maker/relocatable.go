@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Root is an absolute directory referenced in the generated files by a CMake variable
+type Root struct {
+	Variable string
+	Path     string
+}
+
+// RelocatableRoots returns the roots replacing absolute paths in relocatable mode,
+// nested roots come first so the longest matching directory is used
+func (m *Maker) RelocatableRoots() []Root {
+	if !m.Options.Relocatable {
+		return nil
+	}
+	var roots []Root
+	for _, root := range []Root{
+		{Variable: "SOLUTION_ROOT", Path: m.SolutionRoot},
+		{Variable: "CMSIS_PACK_ROOT", Path: m.EnvVars.PackRoot},
+		{Variable: "CMSIS_COMPILER_ROOT", Path: m.EnvVars.CompilerRoot},
+	} {
+		if len(root.Path) > 0 {
+			roots = append(roots, root)
+		}
+	}
+	slices.SortStableFunc(roots, func(a, b Root) int {
+		return len(b.Path) - len(a.Path)
+	})
+	return roots
+}
+
+// RelocatePath replaces the root directory of an absolute path by its variable
+func RelocatePath(input string, roots []Root) string {
+	if !filepath.IsAbs(input) {
+		return input
+	}
+	input = filepath.ToSlash(input)
+	for _, root := range roots {
+		if input == root.Path {
+			return "${" + root.Variable + "}"
+		}
+		if relPath, found := strings.CutPrefix(input, strings.TrimSuffix(root.Path, "/")+"/"); found {
+			return "${" + root.Variable + "}/" + relPath
+		}
+	}
+	return input
+}
+
+// RelativeToTmpDir returns the path relative to the generated roots.cmake for directories
+// inside the solution tree, which move together with it, otherwise the absolute path is kept
+func (m *Maker) RelativeToTmpDir(input string) string {
+	if !strings.HasPrefix(filepath.ToSlash(input)+"/", strings.TrimSuffix(filepath.ToSlash(m.SolutionRoot), "/")+"/") {
+		return input
+	}
+	relPath, err := filepath.Rel(m.SolutionTmpDir, input)
+	if err != nil {
+		return input
+	}
+	return "${CMAKE_CURRENT_LIST_DIR}/" + filepath.ToSlash(relPath)
+}
+
+// CMakeRoot returns the definition of a root taken from the command line, the
+// environment variable or the default path, in this order
+func CMakeRoot(variable string, envVar string, defaultPath string) string {
+	content := "\nif(NOT DEFINED CACHE{" + variable + "})"
+	if len(envVar) > 0 {
+		content += "\n  if(DEFINED ENV{" + envVar + "})" +
+			"\n    file(TO_CMAKE_PATH \"$ENV{" + envVar + "}\" " + variable + ")" +
+			"\n  else()" +
+			"\n    set(" + variable + " " + CMakeQuote(defaultPath) + ")" +
+			"\n  endif()"
+	} else {
+		content += "\n  set(" + variable + " " + CMakeQuote(defaultPath) + ")"
+	}
+	content += "\nendif()"
+	content += "\ncmake_path(ABSOLUTE_PATH " + variable + " NORMALIZE OUTPUT_VARIABLE " + variable + ")"
+	return content
+}
+
+func (m *Maker) CMakeRelocatableRoots() (string, error) {
+	if len(m.EnvVars.PackRoot) == 0 {
+		return "", errors.New("relocatable roots require a CMSIS pack root, set CMSIS_PACK_ROOT")
+	}
+	return `# roots.cmake
+# relocatable: roots are resolved from -D options, environment variables or paths relative to this file` +
+		CMakeRoot("CMSIS_PACK_ROOT", "CMSIS_PACK_ROOT", m.RelativeToTmpDir(m.EnvVars.PackRoot)) +
+		CMakeRoot("CMSIS_COMPILER_ROOT", "CMSIS_COMPILER_ROOT", m.RelativeToTmpDir(m.EnvVars.CompilerRoot)) +
+		CMakeRoot("SOLUTION_ROOT", "", m.RelativeToTmpDir(m.SolutionRoot)) + `
+`, nil
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelocatable(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("GCC_TOOLCHAIN_12_3_0", testRoot+"/run/path/to/gcc1230/bin")
+
+	t.Run("test relocate path", func(t *testing.T) {
+		roots := []maker.Root{
+			{Variable: "CMSIS_PACK_ROOT", Path: "/work/solution/packs"},
+			{Variable: "SOLUTION_ROOT", Path: "/work/solution"},
+		}
+		assert.Equal("${CMSIS_PACK_ROOT}/ARM/CMSIS/6.0.0", maker.RelocatePath("/work/solution/packs/ARM/CMSIS/6.0.0", roots))
+		assert.Equal("${SOLUTION_ROOT}/src/main.c", maker.RelocatePath("/work/solution/src/main.c", roots))
+		assert.Equal("${SOLUTION_ROOT}", maker.RelocatePath("/work/solution", roots))
+		assert.Equal("/work/solution2/main.c", maker.RelocatePath("/work/solution2/main.c", roots))
+		assert.Equal("${SOLUTION_ROOT}/main.c", maker.RelocatePath("${SOLUTION_ROOT}/main.c", roots))
+		assert.Equal("/work/solution/main.c", maker.RelocatePath("/work/solution/main.c", nil))
+	})
+
+	t.Run("test relocatable roots", func(t *testing.T) {
+		var m maker.Maker
+		m.SolutionRoot = "/work/solution"
+		m.EnvVars.PackRoot = "/work/solution/packs"
+		m.EnvVars.CompilerRoot = "/opt/cmsis-toolbox/etc"
+		assert.Nil(m.RelocatableRoots())
+		m.Options.Relocatable = true
+		assert.Equal([]maker.Root{
+			{Variable: "CMSIS_COMPILER_ROOT", Path: "/opt/cmsis-toolbox/etc"},
+			{Variable: "CMSIS_PACK_ROOT", Path: "/work/solution/packs"},
+			{Variable: "SOLUTION_ROOT", Path: "/work/solution"},
+		}, m.RelocatableRoots())
+	})
+
+	t.Run("test relocatable roots outside the solution", func(t *testing.T) {
+		var m maker.Maker
+		m.SolutionRoot = "/work/solution"
+		m.SolutionTmpDir = "/work/solution/tmp"
+		m.EnvVars.PackRoot = "/work/solution/packs"
+		m.EnvVars.CompilerRoot = "/work/cmsis-toolbox/etc"
+		roots, err := m.CMakeRelocatableRoots()
+		assert.Nil(err)
+		assert.Contains(roots, `set(CMSIS_PACK_ROOT "${CMAKE_CURRENT_LIST_DIR}/../packs")`)
+		assert.Contains(roots, `set(CMSIS_COMPILER_ROOT "/work/cmsis-toolbox/etc")`)
+		assert.Contains(roots, `set(SOLUTION_ROOT "${CMAKE_CURRENT_LIST_DIR}/..")`)
+		assert.Equal("/work/solution-other", m.RelativeToTmpDir("/work/solution-other"))
+
+		m.EnvVars.PackRoot = ""
+		_, err = m.CMakeRelocatableRoots()
+		assert.EqualError(err, "relocatable roots require a CMSIS pack root, set CMSIS_PACK_ROOT")
+	})
+
+	t.Run("test cmake root", func(t *testing.T) {
+		assert.Equal(`
+if(NOT DEFINED CACHE{CMSIS_PACK_ROOT})
+  if(DEFINED ENV{CMSIS_PACK_ROOT})
+    file(TO_CMAKE_PATH "$ENV{CMSIS_PACK_ROOT}" CMSIS_PACK_ROOT)
+  else()
+    set(CMSIS_PACK_ROOT "${CMAKE_CURRENT_LIST_DIR}/../packs")
+  endif()
+endif()
+cmake_path(ABSOLUTE_PATH CMSIS_PACK_ROOT NORMALIZE OUTPUT_VARIABLE CMSIS_PACK_ROOT)`,
+			maker.CMakeRoot("CMSIS_PACK_ROOT", "CMSIS_PACK_ROOT", "${CMAKE_CURRENT_LIST_DIR}/../packs"))
+		assert.Equal(`
+if(NOT DEFINED CACHE{SOLUTION_ROOT})
+  set(SOLUTION_ROOT "${CMAKE_CURRENT_LIST_DIR}/..")
+endif()
+cmake_path(ABSOLUTE_PATH SOLUTION_ROOT NORMALIZE OUTPUT_VARIABLE SOLUTION_ROOT)`,
+			maker.CMakeRoot("SOLUTION_ROOT", "", "${CMAKE_CURRENT_LIST_DIR}/.."))
+	})
+
+	t.Run("test relocatable generation", func(t *testing.T) {
+		inputFile := createSyntheticSolution(t, 2, 3)
+		t.Setenv("CMSIS_PACK_ROOT", t.TempDir())
+		var m maker.Maker
+		m.Params.InputFile = inputFile
+		m.Params.Options.Relocatable = true
+		m.Writer = maker.NewFileWriter()
+		assert.Nil(m.GenerateCMakeLists())
+		roots := m.Writer.Files[path.Join(m.SolutionTmpDir, "roots.cmake")]
+		assert.Contains(roots, `set(SOLUTION_ROOT "${CMAKE_CURRENT_LIST_DIR}/..")`)
+		assert.Contains(roots, `file(TO_CMAKE_PATH "$ENV{CMSIS_PACK_ROOT}" CMSIS_PACK_ROOT)`)
+		absSolutionRoot, _ := filepath.Abs(m.SolutionRoot)
+		for filename, content := range m.Writer.Files {
+			assert.False(strings.Contains(content, filepath.ToSlash(absSolutionRoot)), filename)
+		}
+		toolchain := m.Writer.Files[path.Join(m.SolutionTmpDir, m.Cbuilds[0].BuildDescType.Context, "toolchain.cmake")]
+		assert.Contains(toolchain, "if(DEFINED ENV{GCC_TOOLCHAIN_12_3_0})")
+	})
+}
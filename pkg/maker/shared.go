@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
+	"gopkg.in/yaml.v3"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type SharedComponent struct {
+	Fingerprint string
+	Owner       string
+	Consumers   []string
+	Archive     string
+}
+
+// Effective settings that determine the compile commands of components in a context
+type sharedFingerprint struct {
+	Toolchain        string
+	Version          string
+	BaseDir          string
+	Processor        Processor
+	Define           []interface{}
+	DefineAsm        []interface{}
+	AddPath          []string
+	AddPathAsm       []string
+	Misc             Misc
+	Abstractions     CompilerAbstractions
+	ConstructedFiles []string
+	Apis             []Apis
+	Groups           []Groups
+	Components       []Components
+	Component        string
+}
+
+func (m *Maker) ProcessSharedComponents() {
+	fingerprints := make(map[string]*SharedComponent)
+	var order []string
+	unsupported := make(map[string][]string)
+	var toolchains []string
+	for index := range m.Cbuilds {
+		cbuild := &m.Cbuilds[index]
+		cbuild.SharedComponents = make(map[string]*SharedComponent)
+		toolchain := m.RegisteredToolchains[m.SelectedToolchainVersion[index]].Name
+		// whole-archive linking is needed to keep object library semantics
+		if toolchain != "GCC" && toolchain != "CLANG" {
+			toolchains = utils.AppendUniquely(toolchains, toolchain)
+			unsupported[toolchain] = append(unsupported[toolchain], cbuild.BuildDescType.Context)
+			continue
+		}
+		for _, component := range cbuild.BuildDescType.Components {
+			if !IsComponentShareable(component) {
+				continue
+			}
+			fingerprint := cbuild.ComponentFingerprint(toolchain, m.SelectedToolchainVersion[index].String(), component.Component)
+			shared, ok := fingerprints[fingerprint]
+			if !ok {
+				name := ReplaceDelimiters(component.Component)
+				shared = &SharedComponent{
+					Fingerprint: fingerprint,
+					Owner:       cbuild.BuildDescType.Context,
+					Archive:     "${SHARED_COMPONENTS_DIR}/" + fingerprint + "/" + name + ".a",
+				}
+				fingerprints[fingerprint] = shared
+				order = append(order, fingerprint)
+			} else {
+				shared.Consumers = append(shared.Consumers, cbuild.BuildDescType.Context)
+			}
+			cbuild.SharedComponents[ReplaceDelimiters(component.Component)] = shared
+		}
+	}
+
+	for _, toolchain := range toolchains {
+		log.Warn("shared components are not supported for " + toolchain + ", components are built in each context: " +
+			strings.Join(unsupported[toolchain], ", "))
+	}
+
+	// Only components used by more than one context are shared
+	for index := range m.Cbuilds {
+		for name, shared := range m.Cbuilds[index].SharedComponents {
+			if len(shared.Consumers) == 0 {
+				delete(m.Cbuilds[index].SharedComponents, name)
+			}
+		}
+	}
+	m.SharedComponents = nil
+	for _, fingerprint := range order {
+		if shared := fingerprints[fingerprint]; len(shared.Consumers) > 0 {
+			m.SharedComponents = append(m.SharedComponents, *shared)
+			log.Debug("Shared component " + path.Base(shared.Archive) + " (" + fingerprint + ") built by " +
+				shared.Owner + ", used by " + strings.Join(shared.Consumers, ", "))
+		}
+	}
+}
+
+func IsComponentShareable(component Components) bool {
+	if component.Lto || len(component.Generator.ID) > 0 {
+		return false
+	}
+	for _, file := range component.Files {
+		if strings.Contains(file.Category, "source") && file.Attr != "template" {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Cbuild) ComponentFingerprint(toolchain string, version string, component string) string {
+	// relative to the solution root, the fingerprint does not depend on the build machine
+	baseDir := c.BaseDir
+	if relPath, err := filepath.Rel(c.SolutionRoot, c.BaseDir); err == nil && len(c.SolutionRoot) > 0 {
+		baseDir = filepath.ToSlash(relPath)
+	}
+	data := sharedFingerprint{
+		Toolchain:  toolchain,
+		Version:    version,
+		BaseDir:    baseDir,
+		Processor:  c.BuildDescType.Processor,
+		Define:     c.BuildDescType.Define,
+		DefineAsm:  c.BuildDescType.DefineAsm,
+		AddPath:    c.BuildDescType.AddPath,
+		AddPathAsm: c.BuildDescType.AddPathAsm,
+		Misc:       c.BuildDescType.Misc,
+		Abstractions: CompilerAbstractions{c.BuildDescType.Debug, c.BuildDescType.Optimize, c.BuildDescType.Warnings,
+			c.BuildDescType.LanguageC, c.BuildDescType.LanguageCpp},
+		Apis:       c.BuildDescType.Apis,
+		Groups:     StripPlainSources(c.BuildDescType.Groups),
+		Components: c.BuildDescType.Components,
+		Component:  component,
+	}
+	// link options do not affect compilation
+	data.Misc.Link, data.Misc.LinkC, data.Misc.LinkCPP, data.Misc.Library, data.Misc.Lib = nil, nil, nil, nil, nil
+	// constructed files are context specific, compare their content
+	for _, file := range c.BuildDescType.ConstructedFiles {
+		content, err := os.ReadFile(path.Join(c.BaseDir, file.File))
+		if err != nil {
+			data.ConstructedFiles = append(data.ConstructedFiles, file.Category+":"+file.File)
+			continue
+		}
+		hash := sha256.Sum256(content)
+		data.ConstructedFiles = append(data.ConstructedFiles, file.Category+":"+path.Base(file.File)+":"+hex.EncodeToString(hash[:]))
+	}
+	content, _ := yaml.Marshal(data)
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])[:16]
+}
+
+// StripPlainSources removes source files without own options, they do not affect usage requirements
+func StripPlainSources(groups []Groups) []Groups {
+	var stripped []Groups
+	for _, group := range groups {
+		group.Files = slices.DeleteFunc(slices.Clone(group.Files), func(file Files) bool {
+			return strings.Contains(file.Category, "source") && !HasFileCustomOptions(file)
+		})
+		group.Groups = StripPlainSources(group.Groups)
+		stripped = append(stripped, group)
+	}
+	return stripped
+}
+
+func (c *Cbuild) CMakeSharedComponentLibrary(name string) string {
+	shared := c.SharedComponents[name]
+	if shared == nil || shared.Owner != c.BuildDescType.Context {
+		return ""
+	}
+	content := "\nadd_library(" + name + "_SHARED STATIC $<TARGET_OBJECTS:" + name + ">)"
+	content += "\nset_target_properties(" + name + "_SHARED PROPERTIES PREFIX \"\" SUFFIX \".a\" OUTPUT_NAME \"" + name + "\""
+	content += "\n  ARCHIVE_OUTPUT_DIRECTORY \"" + shared.Archive[:strings.LastIndex(shared.Archive, "/")] + "\")"
+	return content
+}
+
+// CMakeSharedComponentsDir defaults the shared archive directory when the context is configured
+// without the super project, the location is common to all contexts of the solution
+func (c *Cbuild) CMakeSharedComponentsDir() string {
+	if len(c.SharedComponents) == 0 {
+		return ""
+	}
+	return `
+if(NOT DEFINED SHARED_COMPONENTS_DIR)
+  set(SHARED_COMPONENTS_DIR "${CMAKE_CURRENT_LIST_DIR}/../shared")
+endif()`
+}
+
+func (c *Cbuild) IsSharedComponentConsumer(name string) bool {
+	shared := c.SharedComponents[name]
+	return shared != nil && shared.Owner != c.BuildDescType.Context
+}
+
+func (m *Maker) SharedComponentsDependencies() string {
+	var dependencies []string
+	for _, shared := range m.SharedComponents {
+		for _, consumer := range shared.Consumers {
+			dependencies = utils.AppendUniquely(dependencies, "\nadd_dependencies("+consumer+"-build "+shared.Owner+"-build)")
+		}
+	}
+	content := strings.Join(dependencies, "")
+	if len(content) > 0 {
+		content = "\n\n# Shared components" + content
+	}
+	return content
+}
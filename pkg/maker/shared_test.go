@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharedComponents(t *testing.T) {
+	assert := assert.New(t)
+
+	component := maker.Components{
+		Component: "ARM::CMSIS:RTOS2:Keil RTX5&Source@5.9.0",
+		Files: []maker.Files{
+			{File: "rtx_lib.c", Category: "sourceC"},
+			{File: "RTX_Config.c", Category: "sourceC", Attr: "config"},
+		},
+	}
+
+	newCbuild := func(context string, link []string) maker.Cbuild {
+		var cbuild maker.Cbuild
+		cbuild.BaseDir = "/project"
+		cbuild.BuildDescType.Context = context
+		cbuild.BuildDescType.Define = []interface{}{"ARMCM0", "_RTE_"}
+		cbuild.BuildDescType.AddPath = []string{"RTE/CMSIS"}
+		cbuild.BuildDescType.Misc.C = []string{"-ffunction-sections"}
+		cbuild.BuildDescType.Misc.Link = link
+		cbuild.BuildDescType.Components = []maker.Components{component}
+		cbuild.BuildDescType.Groups = []maker.Groups{{Group: "Source", Files: []maker.Files{{File: "main.c", Category: "sourceC"}}}}
+		return cbuild
+	}
+
+	t.Run("test component shareable", func(t *testing.T) {
+		assert.True(maker.IsComponentShareable(component))
+		lto := component
+		lto.Lto = true
+		assert.False(maker.IsComponentShareable(lto))
+		headers := maker.Components{Files: []maker.Files{{File: "config.h", Category: "header"}}}
+		assert.False(maker.IsComponentShareable(headers))
+		templates := maker.Components{Files: []maker.Files{{File: "template.c", Category: "sourceC", Attr: "template"}}}
+		assert.False(maker.IsComponentShareable(templates))
+	})
+
+	t.Run("test component fingerprint", func(t *testing.T) {
+		flash := newCbuild("project.Flash+ARMCM0", []string{"-T flash.ld"})
+		ram := newCbuild("project.RAM+ARMCM0", []string{"-T ram.ld"})
+		ram.BuildDescType.Groups[0].Files[0].File = "main_ram.c"
+		fingerprint := flash.ComponentFingerprint("GCC", "13.3.1", component.Component)
+		assert.Len(fingerprint, 16)
+		assert.Equal(fingerprint, ram.ComponentFingerprint("GCC", "13.3.1", component.Component))
+		assert.NotEqual(fingerprint, flash.ComponentFingerprint("GCC", "14.2.1", component.Component))
+
+		debug := newCbuild("project.Debug+ARMCM0", nil)
+		debug.BuildDescType.Define = append(debug.BuildDescType.Define, "DEBUG")
+		assert.NotEqual(fingerprint, debug.ComponentFingerprint("GCC", "13.3.1", component.Component))
+	})
+
+	t.Run("test strip plain sources", func(t *testing.T) {
+		groups := []maker.Groups{{
+			Group: "Source",
+			Files: []maker.Files{
+				{File: "main.c", Category: "sourceC"},
+				{File: "options.c", Category: "sourceC", Define: []interface{}{"OPTION"}},
+				{File: "main.h", Category: "header"},
+			},
+		}}
+		stripped := maker.StripPlainSources(groups)
+		assert.Equal([]maker.Files{groups[0].Files[1], groups[0].Files[2]}, stripped[0].Files)
+		assert.Len(groups[0].Files, 3)
+	})
+
+	t.Run("test shared component owner and consumer", func(t *testing.T) {
+		var m maker.Maker
+		m.SharedComponents = []maker.SharedComponent{{
+			Fingerprint: "0123456789abcdef",
+			Owner:       "project.Flash+ARMCM0",
+			Consumers:   []string{"project.RAM+ARMCM0"},
+			Archive:     "${SHARED_COMPONENTS_DIR}/0123456789abcdef/ARM_CMSIS_RTOS2_Keil_RTX5_Source_5_9_0.a",
+		}}
+		name := "ARM_CMSIS_RTOS2_Keil_RTX5_Source_5_9_0"
+
+		owner := newCbuild("project.Flash+ARMCM0", nil)
+		owner.SharedComponents = map[string]*maker.SharedComponent{name: &m.SharedComponents[0]}
+		assert.False(owner.IsSharedComponentConsumer(name))
+		assert.Equal("\nadd_library("+name+"_SHARED STATIC $<TARGET_OBJECTS:"+name+">)"+
+			"\nset_target_properties("+name+"_SHARED PROPERTIES PREFIX \"\" SUFFIX \".a\" OUTPUT_NAME \""+name+"\""+
+			"\n  ARCHIVE_OUTPUT_DIRECTORY \"${SHARED_COMPONENTS_DIR}/0123456789abcdef\")",
+			owner.CMakeSharedComponentLibrary(name))
+
+		consumer := newCbuild("project.RAM+ARMCM0", nil)
+		consumer.SharedComponents = map[string]*maker.SharedComponent{name: &m.SharedComponents[0]}
+		assert.True(consumer.IsSharedComponentConsumer(name))
+		assert.Empty(consumer.CMakeSharedComponentLibrary(name))
+		assert.Equal("\nif(NOT DEFINED SHARED_COMPONENTS_DIR)\n  set(SHARED_COMPONENTS_DIR \"${CMAKE_CURRENT_LIST_DIR}/../shared\")\nendif()",
+			consumer.CMakeSharedComponentsDir())
+		debug := newCbuild("project.Debug+ARMCM0", nil)
+		assert.Empty(debug.CMakeSharedComponentsDir())
+
+		assert.Equal("\n\n# Shared components\nadd_dependencies(project.RAM+ARMCM0-build project.Flash+ARMCM0-build)",
+			m.SharedComponentsDependencies())
+	})
+
+	t.Run("test unsupported toolchain", func(t *testing.T) {
+		var buffer bytes.Buffer
+		output := log.StandardLogger().Out
+		log.SetOutput(&buffer)
+		defer log.SetOutput(output)
+
+		var m maker.Maker
+		version, _ := semver.NewVersion("6.22.0")
+		m.RegisteredToolchains = map[*semver.Version]maker.Toolchain{version: {Name: "AC6"}}
+		m.SelectedToolchainVersion = []*semver.Version{version, version}
+		m.Cbuilds = []maker.Cbuild{newCbuild("project.Flash+ARMCM0", nil), newCbuild("project.RAM+ARMCM0", nil)}
+		m.ProcessSharedComponents()
+		assert.Empty(m.SharedComponents)
+		assert.Contains(buffer.String(), "shared components are not supported for AC6, components are built in each context: project.Flash+ARMCM0, project.RAM+ARMCM0")
+	})
+}
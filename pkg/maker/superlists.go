@@ -9,27 +9,31 @@ package maker
 import (
 	"path"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
-	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
 	log "github.com/sirupsen/logrus"
 )
 
 const CMAKE_MIN_REQUIRED = "3.27"
 
+const CheckChangesScript = "check_changes.cmake"
+
 func (m *Maker) CreateSuperCMakeLists() error {
 	// Iterate over cbuilds
-	var contexts, dirs, westContextFlags, contextOutputs, compilers string
+	binaryDirs := m.ContextBinaryDirs()
+	var contexts, dirs, binaryDirList, westContextFlags, contextOutputs, compilers string
 	west := false
 	for i, cbuild := range m.Cbuilds {
-		contexts = contexts + "  \"" + strings.ReplaceAll(cbuild.BuildDescType.Context, " ", "_") + "\"\n"
-		dirs = dirs + "  \"${CMAKE_CURRENT_SOURCE_DIR}/" + cbuild.BuildDescType.Context + "\"\n"
+		contexts = contexts + "  " + CMakeQuote(strings.ReplaceAll(cbuild.BuildDescType.Context, " ", "_")) + "\n"
+		dirs = dirs + "  " + CMakeQuote("${CMAKE_CURRENT_SOURCE_DIR}/"+cbuild.BuildDescType.Context) + "\n"
+		binaryDirList = binaryDirList + "  " + CMakeQuote(binaryDirs[i]) + "\n"
 		west = west || (cbuild.BuildDescType.West.AppPath != "")
-		westContextFlags = westContextFlags + "  \"" + strconv.FormatBool(west) + "\"\n"
+		westContextFlags = westContextFlags + "  " + CMakeQuote(strconv.FormatBool(west)) + "\n"
 
-		compilers += "  \"" + m.RegisteredToolchains[m.SelectedToolchainVersion[i]].Name +
-			" V" + m.SelectedToolchainVersion[i].String() + "\"\n"
+		compilers += "  " + CMakeQuote(m.RegisteredToolchains[m.SelectedToolchainVersion[i]].Name+
+			" V"+m.SelectedToolchainVersion[i].String()) + "\n"
 
 		var contextOutputsName = "OUTPUTS_" + strconv.Itoa(i+1)
 		contextOutputs += "\nset(" + contextOutputsName + "\n"
@@ -41,7 +45,7 @@ func (m *Maker) CreateSuperCMakeLists() error {
 			cbuildRelativePath, _ := filepath.Rel(m.SolutionRoot, cbuild.BaseDir)
 			cbuildRelativePath = filepath.ToSlash(cbuildRelativePath)
 			output := cbuild.AddRootPrefix(cbuildRelativePath, path.Join(cbuild.BuildDescType.OutputDirs.Outdir, outputFile))
-			contextOutputs += "  \"" + output + "\"\n"
+			contextOutputs += "  " + CMakeQuote(output) + "\n"
 		}
 
 		contextOutputs += ")"
@@ -66,12 +70,62 @@ func (m *Maker) CreateSuperCMakeLists() error {
 		}
 	}
 
+	buildAlways := "TRUE"
+	var changesStep string
+	if m.Options.Incremental {
+		if strings.HasPrefix(m.Generator(), "Ninja") {
+			if err := m.CMakeCreateCheckChangesScript(); err != nil {
+				return err
+			}
+			// The dry run covers the targets of the build step and runs after the database step
+			// sharing the build tree, unless the database step is excluded from the main build
+			buildArgs, dependees := "", "configure database"
+			if west {
+				buildArgs = " \"-DBUILD_ARGS=" + strings.TrimSpace(westTarget) + "\""
+				dependees = "configure"
+			}
+			buildAlways = "FALSE"
+			changesStep = `
+
+  # Changes check step, the stamp is only updated when the context build tree is out of date
+  ExternalProject_Add_Step(${CONTEXT} changes
+    COMMAND           ${CMAKE_COMMAND} "-DBINARY_DIR=${BINARY_DIR}" "-DSTAMP=${BINARY_DIR}/changes.stamp"` + buildArgs +
+				` -P "${CMAKE_CURRENT_SOURCE_DIR}/` + CheckChangesScript + `"
+    BYPRODUCTS        "${BINARY_DIR}/changes.stamp"
+    ALWAYS            TRUE
+    DEPENDEES         ` + dependees + `
+  )
+  ExternalProject_Add_StepDependencies(${CONTEXT} build "${BINARY_DIR}/changes.stamp")`
+		} else {
+			log.Warn("incremental builds require a Ninja generator, contexts are always built with " + m.Generator())
+		}
+	}
+
+	// Shared components are built in the binary tree of the super project
+	var sharedComponentsDir string
+	if len(m.SharedComponents) > 0 {
+		sharedComponentsDir = "\n  \"-DSHARED_COMPONENTS_DIR=${CMAKE_CURRENT_BINARY_DIR}/shared\""
+	}
+
+	var trustZoneImages string
+	if m.Options.TrustZoneImage {
+		trustZoneImages = m.TrustZoneImages()
+	}
+	combinedImages, err := m.CombinedImagesCommands()
+	if err != nil {
+		return err
+	}
+	var imageTools string
+	if len(trustZoneImages) > 0 || len(combinedImages) > 0 {
+		imageTools = m.CMakeCbuild2cmakeExecutable()
+	}
+
 	// Write content
 	content :=
 		`cmake_minimum_required(VERSION ` + CMAKE_MIN_REQUIRED + `)
 include(ExternalProject)
 	
-project("` + m.SolutionName + `" NONE)
+project(` + CMakeLiteral(m.SolutionName) + ` NONE)
 
 # Enable color diagnostics
 set(CMAKE_COLOR_DIAGNOSTICS ON)
@@ -90,12 +144,15 @@ set(COMPILERS
 
 set(DIRS
 ` + dirs + `)
+
+set(BINARY_DIRS
+` + binaryDirList + `)
 ` + westContexts + contextOutputs + `
 
 set(ARGS
   "-DSOLUTION_ROOT=${SOLUTION_ROOT}"
   "-DCMSIS_PACK_ROOT=${CMSIS_PACK_ROOT}"
-  "-DCMSIS_COMPILER_ROOT=${CMSIS_COMPILER_ROOT}"
+  "-DCMSIS_COMPILER_ROOT=${CMSIS_COMPILER_ROOT}"` + sharedComponentsDir + `
 )
 
 # Compilation database
@@ -107,20 +164,21 @@ foreach(INDEX RANGE ${CONTEXTS_LENGTH})
   math(EXPR N "${INDEX}+1")
   list(GET CONTEXTS ${INDEX} CONTEXT)
   list(GET COMPILERS ${INDEX} COMPILER)
-  list(GET DIRS ${INDEX} DIR)` + westContextCheck + `
+  list(GET DIRS ${INDEX} DIR)
+  list(GET BINARY_DIRS ${INDEX} BINARY_DIR)` + westContextCheck + `
 
   # Create external project, set configure and build steps
   ExternalProject_Add(${CONTEXT}
     PREFIX                ${DIR}
     SOURCE_DIR            ${DIR}
-    BINARY_DIR            ${N}
+    BINARY_DIR            ${BINARY_DIR}
     INSTALL_COMMAND       ""
     TEST_COMMAND          ""
-    CONFIGURE_COMMAND     ${CMAKE_COMMAND} -G Ninja -S <SOURCE_DIR> -B <BINARY_DIR> ${ARGS} 
+    CONFIGURE_COMMAND     ${CMAKE_COMMAND} -G ` + CMakeArgument(m.Generator()) + ` -S <SOURCE_DIR> -B <BINARY_DIR> ${ARGS} 
     BUILD_COMMAND         ${CMAKE_COMMAND} -E cmake_echo_color --blue --bold "Building CMake target '${CONTEXT}'"
     COMMAND               ${CMAKE_COMMAND} -E echo "Using compiler: ${COMPILER}"
     COMMAND               ${CMAKE_COMMAND} --build <BINARY_DIR>` + westTarget + verbosity + `
-    BUILD_ALWAYS          TRUE
+    BUILD_ALWAYS          ` + buildAlways + `
     BUILD_BYPRODUCTS      ${OUTPUTS_${N}}` + logConfigure + `
     USES_TERMINAL_BUILD   ON
   )
@@ -130,10 +188,10 @@ foreach(INDEX RANGE ${CONTEXTS_LENGTH})
     DEPENDEES         build
   )
 
-  ExternalProject_Add_StepTargets(${CONTEXT} build configure executes)
+  ExternalProject_Add_StepTargets(${CONTEXT} build configure executes)` + changesStep + `
 
   # Debug
-  message(VERBOSE "Configure Context: ${CMAKE_COMMAND} -G Ninja -S ${DIR} -B ${N}")
+  message(VERBOSE "Configure Context: ${CMAKE_COMMAND} -G ` + quoteEscaper.Replace(m.Generator()) + ` -S ${DIR} -B ${BINARY_DIR}")
 
   # Database generation step
   ExternalProject_Add_Step(${CONTEXT} database
@@ -145,31 +203,109 @@ foreach(INDEX RANGE ${CONTEXTS_LENGTH})
   ExternalProject_Add_StepTargets(${CONTEXT} database)
   add_dependencies(database ${CONTEXT}-database)
 
-endforeach()` + m.ExecutesCommands(m.CbuildIndex.BuildIdx.Executes) + m.BuildDependencies() + `
+endforeach()` + m.ExecutesCommands(m.CbuildIndex.BuildIdx.Executes) + m.BuildDependencies() + m.SharedComponentsDependencies() + m.TrustZoneDependencies() + imageTools + trustZoneImages + combinedImages + m.CMakeIncludeHooks(HookSolutionEnd, "") + `
 `
 	superCMakeLists := path.Join(m.SolutionTmpDir, "CMakeLists.txt")
-	err := utils.UpdateFile(superCMakeLists, content)
+	err = m.Writer.WriteFile(superCMakeLists, content)
 	if err != nil {
 		return err
 	}
 
-	log.Info("CMakeLists were successfully generated in the " + m.SolutionTmpDir + " directory")
+	if m.Writer == nil {
+		log.Info("CMakeLists were successfully generated in the " + m.SolutionTmpDir + " directory")
+	}
 	return nil
 }
 
+// Ninja status prefix marking the edges of the dry run, the Ninja messages may be localized
+const CheckChangesStatus = "cbuild2cmake-pending: "
+
+// CMakeCreateCheckChangesScript writes the script checking the context build tree with a Ninja dry run
+// of the targets of the build step, the context executes are part of its default target
+func (m *Maker) CMakeCreateCheckChangesScript() error {
+	content := `# check_changes.cmake
+# usage: cmake -DBINARY_DIR=<dir> -DSTAMP=<file> [-DBUILD_ARGS=<args>] -P check_changes.cmake
+separate_arguments(BUILD_ARGS)
+if(EXISTS "${STAMP}" AND EXISTS "${BINARY_DIR}/build.ninja")
+  set(ENV{NINJA_STATUS} "` + CheckChangesStatus + `")
+  execute_process(
+    COMMAND ${CMAKE_COMMAND} --build "${BINARY_DIR}" ${BUILD_ARGS} -- -n
+    OUTPUT_VARIABLE OUTPUT
+    ERROR_VARIABLE OUTPUT
+    RESULT_VARIABLE RESULT
+  )
+  if(RESULT EQUAL 0 AND NOT OUTPUT MATCHES "` + CheckChangesStatus + `")
+    return()
+  endif()
+endif()
+file(TOUCH "${STAMP}")
+`
+	return m.Writer.WriteFile(path.Join(m.SolutionTmpDir, CheckChangesScript), content)
+}
+
+// ContextBinaryDirs returns the build trees of the contexts, stable when contexts are added
+// or filtered: the intdir of the context unless it is shared with the solution tmp dir or
+// another context of the solution, otherwise a directory named after the context in the
+// solution tmp dir
+func (m *Maker) ContextBinaryDirs() []string {
+	intdirs := make([]string, len(m.Cbuilds))
+	count := make(map[string]int)
+	var cbuildFiles []string
+	for i, cbuild := range m.Cbuilds {
+		cbuildFiles = append(cbuildFiles, cbuild.CbuildFile)
+		if len(cbuild.BuildDescType.OutputDirs.Intdir) > 0 {
+			intdirs[i] = path.Clean(path.Join(cbuild.BaseDir, cbuild.BuildDescType.OutputDirs.Intdir))
+			count[intdirs[i]]++
+		}
+	}
+	// Contexts not selected by the context set share intdirs as well
+	for _, cbuildRef := range m.CbuildIndex.BuildIdx.Cbuilds {
+		cbuildFile := path.Join(m.CbuildIndex.BaseDir, cbuildRef.Cbuild)
+		if slices.Contains(cbuildFiles, cbuildFile) {
+			continue
+		}
+		cbuild, err := m.ParseCbuildFile(cbuildFile)
+		if err == nil && len(cbuild.BuildDescType.OutputDirs.Intdir) > 0 {
+			count[path.Clean(path.Join(path.Dir(cbuildFile), cbuild.BuildDescType.OutputDirs.Intdir))]++
+		}
+	}
+	var binaryDirs []string
+	for i, cbuild := range m.Cbuilds {
+		intdir := intdirs[i]
+		if len(intdir) == 0 || count[intdir] > 1 || intdir == m.SolutionTmpDir ||
+			intdir == path.Join(m.SolutionTmpDir, cbuild.BuildDescType.Context) ||
+			strings.HasPrefix(m.SolutionTmpDir+"/", intdir+"/") {
+			log.Debug("Intdir of context " + cbuild.BuildDescType.Context + " is missing or not unique, building in the solution tmp dir")
+			binaryDirs = append(binaryDirs, "${CMAKE_CURRENT_BINARY_DIR}/build/"+cbuild.BuildDescType.Context)
+			continue
+		}
+		cbuildRelativePath, _ := filepath.Rel(m.SolutionRoot, cbuild.BaseDir)
+		cbuildRelativePath = filepath.ToSlash(cbuildRelativePath)
+		binaryDirs = append(binaryDirs, m.AddRootPrefix(cbuildRelativePath, cbuild.BuildDescType.OutputDirs.Intdir))
+	}
+	return binaryDirs
+}
+
 func (m *Maker) CMakeCreateRoots(solutionRoot string) error {
 	content :=
 		`# roots.cmake
-set(CMSIS_PACK_ROOT "` + m.EnvVars.PackRoot + `" CACHE PATH "CMSIS pack root")
+set(CMSIS_PACK_ROOT ` + CMakeQuote(m.EnvVars.PackRoot) + ` CACHE PATH "CMSIS pack root")
 cmake_path(ABSOLUTE_PATH CMSIS_PACK_ROOT NORMALIZE OUTPUT_VARIABLE CMSIS_PACK_ROOT)
-set(CMSIS_COMPILER_ROOT "` + m.EnvVars.CompilerRoot + `" CACHE PATH "CMSIS compiler root")
+set(CMSIS_COMPILER_ROOT ` + CMakeQuote(m.EnvVars.CompilerRoot) + ` CACHE PATH "CMSIS compiler root")
 cmake_path(ABSOLUTE_PATH CMSIS_COMPILER_ROOT NORMALIZE OUTPUT_VARIABLE CMSIS_COMPILER_ROOT)
-set(SOLUTION_ROOT "` + solutionRoot + `" CACHE PATH "CMSIS solution root")
+set(SOLUTION_ROOT ` + CMakeQuote(solutionRoot) + ` CACHE PATH "CMSIS solution root")
 cmake_path(ABSOLUTE_PATH SOLUTION_ROOT NORMALIZE OUTPUT_VARIABLE SOLUTION_ROOT)
 `
+	if m.Options.Relocatable {
+		var err error
+		content, err = m.CMakeRelocatableRoots()
+		if err != nil {
+			return err
+		}
+	}
 
 	filename := path.Join(m.SolutionTmpDir, "roots.cmake")
-	err := utils.UpdateFile(filename, content)
+	err := m.Writer.WriteFile(filename, content)
 	if err != nil {
 		return err
 	}
@@ -178,21 +314,32 @@ cmake_path(ABSOLUTE_PATH SOLUTION_ROOT NORMALIZE OUTPUT_VARIABLE SOLUTION_ROOT)
 }
 
 func (m *Maker) CreateCMakeListsImageOnly() error {
+	combinedImages, err := m.CombinedImagesCommands()
+	if err != nil {
+		return err
+	}
+	var imageTools string
+	if len(combinedImages) > 0 {
+		imageTools = m.CMakeCbuild2cmakeExecutable()
+	}
+
 	// Write content
 	content :=
 		`cmake_minimum_required(VERSION ` + CMAKE_MIN_REQUIRED + `)
 
-project("` + m.SolutionName + `" NONE)
+project(` + CMakeLiteral(m.SolutionName) + ` NONE)
 
 # Roots
-include("roots.cmake")` + m.ExecutesCommands(m.CbuildIndex.BuildIdx.Executes) + m.BuildDependencies() + `
+include("roots.cmake")` + m.ExecutesCommands(m.CbuildIndex.BuildIdx.Executes) + m.BuildDependencies() + imageTools + combinedImages + m.CMakeIncludeHooks(HookSolutionEnd, "") + `
 `
 	pathCMakeLists := path.Join(m.SolutionTmpDir, "CMakeLists.txt")
-	err := utils.UpdateFile(pathCMakeLists, content)
+	err = m.Writer.WriteFile(pathCMakeLists, content)
 	if err != nil {
 		return err
 	}
 
-	log.Info("CMakeLists was successfully generated in the " + m.SolutionTmpDir + " directory")
+	if m.Writer == nil {
+		log.Info("CMakeLists was successfully generated in the " + m.SolutionTmpDir + " directory")
+	}
 	return nil
 }
@@ -12,7 +12,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
-	"sort"
+	"slices"
 	"strings"
 
 	semver "github.com/Masterminds/semver/v3"
@@ -20,10 +20,16 @@ import (
 )
 
 type Toolchain struct {
-	Name string
-	Path string
+	Name   string
+	Path   string
+	EnvVar string
 }
 
+var (
+	toolchainConfigPattern = regexp.MustCompile(`(\w+)\.(\d+\.\d+\.\d+).cmake`)
+	toolchainEnvVarPattern = regexp.MustCompile(`(\w+)_TOOLCHAIN_(\d+)_(\d+)_(\d+)=(.*)`)
+)
+
 func (m *Maker) ProcessToolchain() error {
 
 	toolchainFiles, err := os.ReadDir(m.EnvVars.CompilerRoot)
@@ -34,9 +40,8 @@ func (m *Maker) ProcessToolchain() error {
 
 	// Toolchain configs
 	m.ToolchainConfigs = make(map[*semver.Version]Toolchain)
-	pattern := regexp.MustCompile(`(\w+)\.(\d+\.\d+\.\d+).cmake`)
 	for _, toolchainFile := range toolchainFiles {
-		matched := pattern.FindAllStringSubmatch(toolchainFile.Name(), -1)
+		matched := toolchainConfigPattern.FindAllStringSubmatch(toolchainFile.Name(), -1)
 		if matched == nil {
 			continue
 		}
@@ -60,15 +65,15 @@ func (m *Maker) ProcessToolchain() error {
 	// Registered toolchains
 	m.RegisteredToolchains = make(map[*semver.Version]Toolchain)
 	systemEnvVars := os.Environ()
-	pattern = regexp.MustCompile(`(\w+)_TOOLCHAIN_(\d+)_(\d+)_(\d+)=(.*)`)
 	for _, systemEnvVar := range systemEnvVars {
-		matched := pattern.FindAllStringSubmatch(systemEnvVar, -1)
+		matched := toolchainEnvVarPattern.FindAllStringSubmatch(systemEnvVar, -1)
 		if matched == nil {
 			continue
 		}
 		var toolchain Toolchain
 		toolchain.Name = matched[0][1]
 		toolchain.Path = filepath.ToSlash(matched[0][5])
+		toolchain.EnvVar, _, _ = strings.Cut(systemEnvVar, "=")
 		version, _ := semver.NewVersion(matched[0][2] + "." + matched[0][3] + "." + matched[0][4])
 		m.RegisteredToolchains[version] = toolchain
 
@@ -112,7 +117,7 @@ func (m *Maker) ProcessToolchain() error {
 			err := errors.New("no toolchain configuration file was found for " + contextToolchain)
 			return err
 		}
-		sort.Sort(sort.Reverse(semver.Collection(configVersions)))
+		slices.SortStableFunc(configVersions, CompareVersionsDescending)
 		var registeredVersions []*semver.Version
 		for version, registeredToolchain := range m.RegisteredToolchains {
 			if registeredToolchain.Name == contextToolchain {
@@ -123,7 +128,7 @@ func (m *Maker) ProcessToolchain() error {
 			err := errors.New("compiler registration environment variable missing, format: " + contextToolchain + "_TOOLCHAIN_<major>_<minor>_<patch>")
 			return err
 		}
-		sort.Sort(sort.Reverse(semver.Collection(registeredVersions)))
+		slices.SortStableFunc(registeredVersions, CompareVersionsDescending)
 
 		// Get latest compatible registered version
 		compatible := false
@@ -162,3 +167,12 @@ func (m *Maker) ProcessToolchain() error {
 
 	return nil
 }
+
+// CompareVersionsDescending orders versions from latest to oldest, versions with equal precedence
+// such as differing only in build metadata are ordered by their original string to stay deterministic
+func CompareVersionsDescending(a *semver.Version, b *semver.Version) int {
+	if result := b.Compare(a); result != 0 {
+		return result
+	}
+	return strings.Compare(a.Original(), b.Original())
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/Masterminds/semver/v3"
@@ -119,4 +120,16 @@ func TestToolchain(t *testing.T) {
 		assert.Error(err)
 		assert.ErrorContains(err, "reading directory failed")
 	})
+
+	t.Run("test compare versions descending", func(t *testing.T) {
+		versions := []*semver.Version{
+			semver.MustParse("10.3.1+b"), semver.MustParse("12.2.0"), semver.MustParse("10.3.1+a"), semver.MustParse("9.2.1"),
+		}
+		slices.SortStableFunc(versions, maker.CompareVersionsDescending)
+		var sorted []string
+		for _, version := range versions {
+			sorted = append(sorted, version.Original())
+		}
+		assert.Equal([]string{"12.2.0", "10.3.1+a", "10.3.1+b", "9.2.1"}, sorted)
+	})
 }
@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker
+
+import (
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type TrustZonePair struct {
+	Secure    int
+	NonSecure int
+	CmseLib   string
+	DependsOn bool
+}
+
+// ProcessTrustZonePairs identifies secure contexts with cmse-lib output and the non-secure
+// contexts consuming the CMSE import library, either as link input or via depends-on
+func (m *Maker) ProcessTrustZonePairs() {
+	m.TrustZonePairs = nil
+	for secure := range m.Cbuilds {
+		if !isSecure(m.Cbuilds[secure].BuildDescType.Processor.Trustzone) {
+			continue
+		}
+		for _, output := range m.Cbuilds[secure].BuildDescType.Output {
+			if output.Type != "cmse-lib" {
+				continue
+			}
+			cmseLib := m.ContextOutputPath(secure, output.File)
+			for nonSecure := range m.Cbuilds {
+				if m.Cbuilds[nonSecure].BuildDescType.Processor.Trustzone != "non-secure" {
+					continue
+				}
+				dependsOn := slices.Contains(m.DependsOnContexts(nonSecure), secure)
+				if !dependsOn && !slices.Contains(m.LinkInputs(nonSecure), cmseLib) {
+					continue
+				}
+				m.TrustZonePairs = append(m.TrustZonePairs, TrustZonePair{
+					Secure:    secure,
+					NonSecure: nonSecure,
+					CmseLib:   cmseLib,
+					DependsOn: dependsOn,
+				})
+				log.Debug("TrustZone pair: " + m.Cbuilds[secure].BuildDescType.Context + " (secure), " +
+					m.Cbuilds[nonSecure].BuildDescType.Context + " (non-secure)")
+			}
+		}
+	}
+}
+
+// ContextOutputPath returns the path of an output file of a context relative to the solution root
+func (m *Maker) ContextOutputPath(index int, file string) string {
+	cbuild := &m.Cbuilds[index]
+	contextRoot, _ := filepath.Rel(m.SolutionRoot, cbuild.BaseDir)
+	return cbuild.AddRootPrefix(filepath.ToSlash(contextRoot), path.Join(cbuild.BuildDescType.OutputDirs.Outdir, file))
+}
+
+// LinkInputs returns the library and object files of a context
+func (m *Maker) LinkInputs(index int) []string {
+	cbuild := &m.Cbuilds[index]
+	contextRoot, _ := filepath.Rel(m.SolutionRoot, cbuild.BaseDir)
+	contextRoot = filepath.ToSlash(contextRoot)
+	var inputs []string
+	var collect func(files []Files)
+	collect = func(files []Files) {
+		for _, file := range files {
+			if file.Category == "library" || file.Category == "object" {
+				inputs = utils.AppendUniquely(inputs, cbuild.AddRootPrefix(contextRoot, file.File))
+			}
+		}
+	}
+	var collectGroups func(groups []Groups)
+	collectGroups = func(groups []Groups) {
+		for _, group := range groups {
+			collect(group.Files)
+			collectGroups(group.Groups)
+		}
+	}
+	collectGroups(cbuild.BuildDescType.Groups)
+	for _, component := range cbuild.BuildDescType.Components {
+		collect(component.Files)
+	}
+	return inputs
+}
+
+// TrustZoneDependencies orders the build of non-secure contexts after their secure counterpart
+func (m *Maker) TrustZoneDependencies() string {
+	var content string
+	for _, pair := range m.TrustZonePairs {
+		if pair.DependsOn {
+			// already covered by build dependencies
+			continue
+		}
+		content += "\nadd_dependencies(" + m.Cbuilds[pair.NonSecure].BuildDescType.Context + "-build " +
+			m.Cbuilds[pair.Secure].BuildDescType.Context + "-build)"
+	}
+	if len(content) > 0 {
+		content = "\n\n# TrustZone pairs" + content
+	}
+	return content
+}
+
+// TrustZoneImages creates targets combining the hex outputs of secure and non-secure contexts,
+// the images are merged by address and overlaps are reported
+func (m *Maker) TrustZoneImages() string {
+	var content string
+	for _, pair := range m.TrustZonePairs {
+		secure := &m.Cbuilds[pair.Secure]
+		nonSecure := &m.Cbuilds[pair.NonSecure]
+		secureHex := GetOutputFile(secure.BuildDescType.Output, "hex")
+		nonSecureHex := GetOutputFile(nonSecure.BuildDescType.Output, "hex")
+		if len(secureHex) == 0 || len(nonSecureHex) == 0 {
+			log.Warn("TrustZone image of " + secure.BuildDescType.Context + " and " + nonSecure.BuildDescType.Context +
+				" requires hex outputs of both contexts")
+			continue
+		}
+		inputs := []string{m.ContextOutputPath(pair.Secure, secureHex), m.ContextOutputPath(pair.NonSecure, nonSecureHex)}
+		output := m.ContextOutputPath(pair.NonSecure, strings.TrimSuffix(nonSecureHex, path.Ext(nonSecureHex))+"_tz")
+		outputs := []string{output + ".hex", output + ".layout.txt"}
+		arguments := append([]string{"--format=hex", "--output=" + output, "--report=" + output + ".layout.txt"}, inputs...)
+		content += "\n\n# TrustZone image " + secure.BuildDescType.Context + " + " + nonSecure.BuildDescType.Context
+		content += CMakeCombineImagesTarget(nonSecure.BuildDescType.Context+"-tz-image", outputs, arguments, inputs,
+			[]string{secure.BuildDescType.Context + "-build", nonSecure.BuildDescType.Context + "-build"})
+	}
+	return content
+}
+
+func GetOutputFile(outputList []Output, outputType string) string {
+	for _, output := range outputList {
+		if output.Type == outputType {
+			return output.File
+		}
+	}
+	return ""
+}
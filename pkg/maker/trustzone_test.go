@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrustZone(t *testing.T) {
+	assert := assert.New(t)
+
+	newMaker := func(dependsOn []string, nsFiles []maker.Files) maker.Maker {
+		var m maker.Maker
+		m.SolutionRoot = "/solution"
+		m.CbuildIndex.BuildIdx.Cbuilds = []maker.Cbuilds{
+			{Project: "s", Configuration: ".debug+target"},
+			{Project: "ns", Configuration: ".debug+target", DependsOn: dependsOn},
+		}
+		m.Cbuilds = make([]maker.Cbuild, 2)
+		m.Cbuilds[0].BaseDir = "/solution/s"
+		m.Cbuilds[0].BuildDescType.Context = "s.debug+target"
+		m.Cbuilds[0].BuildDescType.Processor.Trustzone = "secure"
+		m.Cbuilds[0].BuildDescType.OutputDirs.Outdir = "../out/s"
+		m.Cbuilds[0].BuildDescType.Output = []maker.Output{
+			{Type: "elf", File: "s.elf"},
+			{Type: "hex", File: "s.hex"},
+			{Type: "cmse-lib", File: "s_CMSE_Lib.o"},
+		}
+		m.Cbuilds[1].BaseDir = "/solution/ns"
+		m.Cbuilds[1].BuildDescType.Context = "ns.debug+target"
+		m.Cbuilds[1].BuildDescType.Processor.Trustzone = "non-secure"
+		m.Cbuilds[1].BuildDescType.OutputDirs.Outdir = "../out/ns"
+		m.Cbuilds[1].BuildDescType.Output = []maker.Output{
+			{Type: "elf", File: "ns.elf"},
+			{Type: "hex", File: "ns.hex"},
+		}
+		m.Cbuilds[1].BuildDescType.Groups = []maker.Groups{{Group: "CMSE", Files: nsFiles}}
+		return m
+	}
+
+	t.Run("test pairing via cmse library input", func(t *testing.T) {
+		m := newMaker(nil, []maker.Files{{File: "../out/s/s_CMSE_Lib.o", Category: "object"}})
+		m.ProcessTrustZonePairs()
+		assert.Equal([]maker.TrustZonePair{{Secure: 0, NonSecure: 1, CmseLib: "${SOLUTION_ROOT}/out/s/s_CMSE_Lib.o"}}, m.TrustZonePairs)
+		assert.Equal([]string{"${SOLUTION_ROOT}/out/s/s_CMSE_Lib.o"}, m.LinkInputs(1))
+		assert.Equal([]int{0}, m.DependencyContexts(1))
+		assert.Equal([]string{"${SOLUTION_ROOT}/out/s/s_CMSE_Lib.o"}, m.DependencyLibraries(1))
+		assert.Equal("\n\n# TrustZone pairs\nadd_dependencies(ns.debug+target-build s.debug+target-build)", m.TrustZoneDependencies())
+	})
+
+	t.Run("test pairing via depends-on", func(t *testing.T) {
+		m := newMaker([]string{"s.debug+target"}, nil)
+		m.ProcessTrustZonePairs()
+		assert.Len(m.TrustZonePairs, 1)
+		assert.True(m.TrustZonePairs[0].DependsOn)
+		assert.Empty(m.TrustZoneDependencies())
+	})
+
+	t.Run("test no pairing", func(t *testing.T) {
+		m := newMaker(nil, []maker.Files{{File: "other.o", Category: "object"}})
+		m.ProcessTrustZonePairs()
+		assert.Empty(m.TrustZonePairs)
+
+		m = newMaker([]string{"s.debug+target"}, nil)
+		m.Cbuilds[1].BuildDescType.Processor.Trustzone = ""
+		m.ProcessTrustZonePairs()
+		assert.Empty(m.TrustZonePairs)
+	})
+
+	t.Run("test trustzone image", func(t *testing.T) {
+		m := newMaker([]string{"s.debug+target"}, nil)
+		m.ProcessTrustZonePairs()
+		assert.Equal(`
+
+# TrustZone image s.debug+target + ns.debug+target
+add_custom_command(OUTPUT "${SOLUTION_ROOT}/out/ns/ns_tz.hex" "${SOLUTION_ROOT}/out/ns/ns_tz.layout.txt"
+  COMMAND "${CBUILD2CMAKE}" combine-images "--format=hex" "--output=${SOLUTION_ROOT}/out/ns/ns_tz" "--report=${SOLUTION_ROOT}/out/ns/ns_tz.layout.txt" "${SOLUTION_ROOT}/out/s/s.hex" "${SOLUTION_ROOT}/out/ns/ns.hex"
+  DEPENDS "${SOLUTION_ROOT}/out/s/s.hex" "${SOLUTION_ROOT}/out/ns/ns.hex"
+  VERBATIM
+)
+add_custom_target(ns.debug+target-tz-image ALL DEPENDS "${SOLUTION_ROOT}/out/ns/ns_tz.hex" "${SOLUTION_ROOT}/out/ns/ns_tz.layout.txt")
+add_dependencies(ns.debug+target-tz-image s.debug+target-build ns.debug+target-build)`, m.TrustZoneImages())
+
+		m.Cbuilds[1].BuildDescType.Output = m.Cbuilds[1].BuildDescType.Output[:1]
+		assert.Empty(m.TrustZoneImages())
+	})
+}
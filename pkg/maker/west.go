@@ -23,6 +23,7 @@ var WestToolchainMap = map[string]string{
 
 func (m *Maker) CreateWestCMakeLists(index int) error {
 	cbuild := &m.Cbuilds[index]
+	cbuild.Writer = m.Writer
 	cbuild.ContextRoot, _ = filepath.Rel(m.SolutionRoot, cbuild.BaseDir)
 	cbuild.ContextRoot = filepath.ToSlash(cbuild.ContextRoot)
 	cbuild.Toolchain = m.RegisteredToolchains[m.SelectedToolchainVersion[index]].Name
@@ -34,7 +35,7 @@ func (m *Maker) CreateWestCMakeLists(index int) error {
 	var westOptions, westDefs string
 	var westOptionsRef, westDefsRef string
 	for _, opt := range cbuild.BuildDescType.West.WestOpt {
-		westOptions += "\n  " + opt
+		westOptions += "\n  " + CMakeArgument(opt)
 	}
 	if len(westOptions) > 0 {
 		westOptions = "\nset(WEST_OPTIONS" + westOptions + "\n)"
@@ -47,7 +48,7 @@ func (m *Maker) CreateWestCMakeLists(index int) error {
 		if len(value) > 0 {
 			def += "=" + value
 		}
-		westDefs += "\n  -D" + def
+		westDefs += "\n  " + CMakeArgument("-D"+def)
 	}
 	if len(westDefs) > 0 {
 		westDefs = "\nset(WEST_DEFS" + westDefs + "\n)"
@@ -68,9 +69,9 @@ include("../roots.cmake")
 
 set(CONTEXT ` + strings.ReplaceAll(cbuild.BuildDescType.Context, " ", "_") + `)
 set(TARGET ${CONTEXT})
-set(OUT_DIR "` + outDir + `")
-set(WEST_BOARD "` + cbuild.BuildDescType.West.Board + `")
-set(WEST_APP "` + westApp + `")
+set(OUT_DIR ` + CMakeQuote(outDir) + `)
+set(WEST_BOARD ` + CMakeLiteral(cbuild.BuildDescType.West.Board) + `)
+set(WEST_APP ` + CMakeQuote(westApp) + `)
 
 # Toolchain config map
 include("toolchain.cmake")
@@ -113,7 +114,7 @@ add_custom_target(west
 `
 	// Update CMakeLists.txt
 	contextCMakeLists := path.Join(contextDir, "CMakeLists.txt")
-	err = utils.UpdateFile(contextCMakeLists, content)
+	err = m.Writer.WriteFile(contextCMakeLists, content)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// FileWriter collects generated files in memory, a nil writer writes them to disk
+type FileWriter struct {
+	mutex sync.Mutex
+	Files map[string]string
+}
+
+func NewFileWriter() *FileWriter {
+	return &FileWriter{Files: make(map[string]string)}
+}
+
+func (w *FileWriter) WriteFile(filename string, content string) error {
+	if w == nil {
+		return utils.UpdateFile(filename, content)
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.Files[filename] = content
+	return nil
+}
+
+// Differences returns the sorted names of files missing in one of the writers or having different content
+func (w *FileWriter) Differences(other *FileWriter) []string {
+	var differences []string
+	for filename, content := range w.Files {
+		if otherContent, ok := other.Files[filename]; !ok || otherContent != content {
+			differences = append(differences, filename)
+		}
+	}
+	for filename := range other.Files {
+		if _, ok := w.Files[filename]; !ok {
+			differences = append(differences, filename)
+		}
+	}
+	slices.Sort(differences)
+	return differences
+}
+
+// CheckReproducible generates the CMake files twice in memory and fails if both runs differ,
+// nothing is written to disk
+func CheckReproducible(params Params) error {
+	var writers []*FileWriter
+	for range 2 {
+		m := Maker{Params: params}
+		m.Writer = NewFileWriter()
+		err := m.GenerateCMakeLists()
+		if err != nil {
+			return err
+		}
+		writers = append(writers, m.Writer)
+	}
+	differences := writers[0].Differences(writers[1])
+	if len(differences) > 0 {
+		return errors.New("generated output is not reproducible:\n  " + strings.Join(differences, "\n  "))
+	}
+	log.Info("Generated output is reproducible: " + strconv.Itoa(len(writers[0].Files)) + " files compared")
+	return nil
+}
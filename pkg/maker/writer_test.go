@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package maker_test
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/maker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("GCC_TOOLCHAIN_12_3_0", testRoot+"/run/path/to/gcc1230/bin")
+
+	t.Run("test in-memory generation matches files on disk", func(t *testing.T) {
+		inputFile := createSyntheticSolution(t, 3, 5)
+		tmpDir := path.Join(path.Dir(inputFile), "tmp")
+
+		var memory maker.Maker
+		memory.Params.InputFile = inputFile
+		memory.Writer = maker.NewFileWriter()
+		assert.Nil(memory.GenerateCMakeLists())
+		_, err := os.Stat(tmpDir)
+		assert.True(os.IsNotExist(err))
+
+		var disk maker.Maker
+		disk.Params.InputFile = inputFile
+		assert.Nil(disk.GenerateCMakeLists())
+		files := make(map[string]string)
+		for filename, content := range memory.Writer.Files {
+			files[strings.TrimPrefix(filename, tmpDir)] = content
+		}
+		assert.Equal(readTree(tmpDir), files)
+	})
+
+	t.Run("test check reproducible", func(t *testing.T) {
+		inputFile := createSyntheticSolution(t, 4, 10)
+		assert.Nil(maker.CheckReproducible(maker.Params{InputFile: inputFile, Options: maker.Options{Jobs: 4}}))
+		_, err := os.Stat(path.Join(path.Dir(inputFile), "tmp"))
+		assert.True(os.IsNotExist(err))
+	})
+
+	t.Run("test check reproducible with invalid input", func(t *testing.T) {
+		err := maker.CheckReproducible(maker.Params{InputFile: path.Join(t.TempDir(), "missing.cbuild-idx.yml")})
+		assert.Error(err)
+	})
+
+	t.Run("test differences", func(t *testing.T) {
+		first := maker.NewFileWriter()
+		second := maker.NewFileWriter()
+		assert.Nil(first.WriteFile("b/CMakeLists.txt", "b"))
+		assert.Nil(first.WriteFile("a/CMakeLists.txt", "a"))
+		assert.Nil(first.WriteFile("roots.cmake", "roots"))
+		assert.Nil(second.WriteFile("a/CMakeLists.txt", "a"))
+		assert.Nil(second.WriteFile("b/CMakeLists.txt", "B"))
+		assert.Nil(second.WriteFile("c/CMakeLists.txt", "c"))
+		assert.Equal([]string{"b/CMakeLists.txt", "c/CMakeLists.txt", "roots.cmake"}, first.Differences(second))
+		assert.Empty(first.Differences(first))
+	})
+}
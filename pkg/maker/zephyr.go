@@ -63,7 +63,11 @@ func (m *Maker) GenerateZephyrModules() error {
 				layerVendor, layerName, _ := utils.ExtractPackIdParts(layerPack.Pack)
 				if vendor == layerVendor && name == layerName {
 					zephyrLayer.Packs = append(zephyrLayer.Packs, pack)
-					if filepath.IsAbs(pack.Path) || strings.HasPrefix(pack.Path, "${") {
+					if m.Options.Relocatable && strings.HasPrefix(RelocatePath(pack.Path, m.Roots), "${SOLUTION_ROOT}") {
+						// absolute paths in the solution become relative to the module
+						packPath, _ := filepath.Rel(path.Join(m.SolutionRoot, m.SolutionName), filepath.ToSlash(pack.Path))
+						m.ZephyrMaker.PackPaths[pack.Pack] = "${CMAKE_CURRENT_LIST_DIR}/" + filepath.ToSlash(packPath)
+					} else if filepath.IsAbs(pack.Path) || strings.HasPrefix(pack.Path, "${") {
 						m.ZephyrMaker.PackPaths[pack.Pack] = pack.Path
 					} else {
 						packPath, _ := filepath.Rel(path.Join(m.SolutionRoot, m.SolutionName), (path.Join(m.ZephyrMaker.Cbuild.BaseDir, pack.Path)))
@@ -109,7 +113,9 @@ func (m *Maker) GenerateZephyrModules() error {
 		src := path.Join(m.ZephyrMaker.Cbuild.BaseDir, file.File)
 		dst := path.Join(m.SolutionRoot, m.SolutionName, path.Base(file.File))
 		data, err := os.ReadFile(src)
-		if err == nil {
+		if err == nil && m.Writer != nil {
+			_ = m.Writer.WriteFile(dst, string(data))
+		} else if err == nil {
 			_ = os.MkdirAll(filepath.Dir(dst), 0o755)
 			// #nosec G703 -- safe dst
 			_ = os.WriteFile(dst, data, 0o600)
@@ -154,7 +160,7 @@ build:
 `
 	// Write module.yml
 	moduleYml := path.Join(m.SolutionRoot, m.SolutionName, "zephyr", "module.yml")
-	err := utils.UpdateFile(moduleYml, content)
+	err := m.Writer.WriteFile(moduleYml, content)
 	if err != nil {
 		return err
 	}
@@ -194,7 +200,7 @@ func (m *Maker) GenerateModuleKconfig() error {
 
 	// Write Kconfig
 	kconfig := path.Join(m.SolutionRoot, m.SolutionName, "Kconfig")
-	err := utils.UpdateFile(kconfig, content)
+	err := m.Writer.WriteFile(kconfig, content)
 	if err != nil {
 		return err
 	}
@@ -214,7 +220,7 @@ func (m *Maker) GenerateModuleCMakeLists() error {
 
 	// Write CMakeLists.txt
 	cmakeLists := path.Join(m.SolutionRoot, m.SolutionName, "CMakeLists.txt")
-	err := utils.UpdateFile(cmakeLists, content)
+	err := m.Writer.WriteFile(cmakeLists, content)
 	if err != nil {
 		return err
 	}
@@ -231,10 +237,10 @@ func (m *Maker) GenerateModuleCMakeSources() error {
 			packId := pack.Key
 			packPath := pack.Value
 			packName := strings.ToUpper(ReplaceSpecialChars(strings.SplitN(packId, "@", 2)[0]))
-			content += "cmake_path(SET " + packName + " NORMALIZE \"" + packPath + "\")\n"
+			content += "cmake_path(SET " + packName + " NORMALIZE " + CMakeQuote(packPath) + ")\n"
 			vendor, name, version := utils.ExtractPackIdParts(packId)
 			pdsc := vendor + "." + name + ".pdsc"
-			pdscs = append(pdscs, "\"${"+packName+"}/"+pdsc+"\"")
+			pdscs = append(pdscs, CMakeQuote("${"+packName+"}/"+pdsc))
 			if strings.HasPrefix(packPath, "${CMAKE_CURRENT_LIST_DIR}") {
 				fallback += `if(NOT EXISTS "${` + packName + `}/` + pdsc + `")
   # Fallback to CMSIS_PACK_ROOT
@@ -313,7 +319,7 @@ zephyr_compile_options(` + compileOptions + `
 				content += "  zephyr_library_sources("
 				for _, language := range sortedmap.AsSortedMap(m.ZephyrMaker.ComponentFiles[component.Component].Source) {
 					for _, file := range language.Value {
-						content += "\n    \"" + m.GetFilePath(file, component.FromPack) + "\""
+						content += "\n    " + CMakeQuote(m.GetFilePath(file, component.FromPack))
 					}
 				}
 				content += "\n  )\n\n"
@@ -326,7 +332,7 @@ zephyr_compile_options(` + compileOptions + `
 			if len(libraries) > 0 {
 				content += "  zephyr_library_import("
 				for _, file := range libraries {
-					content += "\n    \"" + m.GetFilePath(file, component.FromPack) + "\""
+					content += "\n    " + CMakeQuote(m.GetFilePath(file, component.FromPack))
 				}
 				content += "\n  )\n\n"
 			}
@@ -337,7 +343,7 @@ zephyr_compile_options(` + compileOptions + `
 				for _, languages := range sortedmap.AsSortedMap(m.ZephyrMaker.ComponentFiles[component.Component].Include) {
 					for _, files := range languages.Value {
 						for _, file := range files {
-							content += "\n    \"" + m.GetFilePath(file, component.FromPack) + "\""
+							content += "\n    " + CMakeQuote(m.GetFilePath(file, component.FromPack))
 						}
 					}
 				}
@@ -357,7 +363,7 @@ zephyr_compile_options(` + compileOptions + `
 
 	// Write sources.cmake
 	cmakeSources := path.Join(m.SolutionRoot, m.SolutionName, "sources.cmake")
-	err := utils.UpdateFile(cmakeSources, content)
+	err := m.Writer.WriteFile(cmakeSources, content)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,224 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Largest binary image written, gaps between segments are filled
+const MaxBinaryImageSize = 64 * 1024 * 1024
+
+type Segment struct {
+	Address uint64
+	Data    []byte
+}
+
+type Image struct {
+	Name     string
+	Segments []Segment
+}
+
+func (s Segment) End() uint64 {
+	return s.Address + uint64(len(s.Data))
+}
+
+// ReadImage reads an Intel HEX file or a binary file placed at the given load address
+func ReadImage(file string, loadAddress *uint64) (Image, error) {
+	image := Image{Name: path.Base(file)}
+	var err error
+	if strings.EqualFold(path.Ext(file), ".hex") {
+		if loadAddress != nil {
+			return image, errors.New("load address is not supported for hex image " + file)
+		}
+		image.Segments, err = ReadIntelHex(file)
+		return image, err
+	}
+	if loadAddress == nil {
+		return image, errors.New("load address is required for binary image " + file)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return image, err
+	}
+	image.Segments = []Segment{{Address: *loadAddress, Data: data}}
+	return image, nil
+}
+
+func ReadIntelHex(file string) ([]Segment, error) {
+	content, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	var segments []Segment
+	var base uint64
+	scanner := bufio.NewScanner(content)
+	for line := 1; scanner.Scan(); line++ {
+		record := strings.TrimSpace(scanner.Text())
+		if len(record) == 0 {
+			continue
+		}
+		location := fmt.Sprintf("%s:%d", file, line)
+		if record[0] != ':' {
+			return nil, errors.New(location + ": invalid record")
+		}
+		bytes, err := hex.DecodeString(record[1:])
+		if err != nil || len(bytes) < 5 || len(bytes) != int(bytes[0])+5 {
+			return nil, errors.New(location + ": invalid record")
+		}
+		var checksum byte
+		for _, b := range bytes {
+			checksum += b
+		}
+		if checksum != 0 {
+			return nil, errors.New(location + ": checksum mismatch")
+		}
+		offset := uint64(bytes[1])<<8 | uint64(bytes[2])
+		data := bytes[4 : len(bytes)-1]
+		if size, ok := recordSizes[bytes[3]]; ok && len(data) != size {
+			return nil, fmt.Errorf("%s: invalid byte count for record type %02X", location, bytes[3])
+		}
+		switch bytes[3] {
+		case 0x00:
+			address := base + offset
+			if last := len(segments) - 1; last >= 0 && segments[last].End() == address {
+				segments[last].Data = append(segments[last].Data, data...)
+			} else {
+				segments = append(segments, Segment{Address: address, Data: append([]byte{}, data...)})
+			}
+		case 0x01:
+			return segments, nil
+		case 0x02:
+			base = (uint64(data[0])<<8 | uint64(data[1])) << 4
+		case 0x04:
+			base = (uint64(data[0])<<8 | uint64(data[1])) << 16
+		}
+	}
+	return segments, scanner.Err()
+}
+
+// Data sizes of the Intel HEX address records
+var recordSizes = map[byte]int{0x02: 2, 0x03: 4, 0x04: 2, 0x05: 4}
+
+// MergeImages combines the segments of all images and fails on overlapping address ranges
+func MergeImages(images []Image) ([]Segment, error) {
+	type placed struct {
+		Segment
+		name string
+	}
+	var all []placed
+	for _, image := range images {
+		for _, segment := range image.Segments {
+			if len(segment.Data) > 0 {
+				all = append(all, placed{segment, image.Name})
+			}
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Address < all[j].Address })
+
+	var overlaps []string
+	var merged []Segment
+	var reach *placed
+	for i, segment := range all {
+		if reach != nil && segment.Address < reach.End() {
+			overlaps = append(overlaps, fmt.Sprintf("%s [0x%08X-0x%08X] overlaps %s [0x%08X-0x%08X]",
+				segment.name, segment.Address, segment.End()-1, reach.name, reach.Address, reach.End()-1))
+			if segment.End() > reach.End() {
+				reach = &all[i]
+			}
+			continue
+		}
+		reach = &all[i]
+		if last := len(merged) - 1; last >= 0 && merged[last].End() == segment.Address {
+			merged[last].Data = append(merged[last].Data, segment.Data...)
+		} else {
+			merged = append(merged, Segment{Address: segment.Address, Data: append([]byte{}, segment.Data...)})
+		}
+	}
+	if len(overlaps) > 0 {
+		return nil, errors.New("overlapping address ranges:\n  " + strings.Join(overlaps, "\n  "))
+	}
+	return merged, nil
+}
+
+func WriteIntelHex(file string, segments []Segment) error {
+	var content strings.Builder
+	record := func(recordType byte, offset uint16, data []byte) {
+		bytes := append([]byte{byte(len(data)), byte(offset >> 8), byte(offset), recordType}, data...)
+		var checksum byte
+		for _, b := range bytes {
+			checksum += b
+		}
+		content.WriteString(":" + strings.ToUpper(hex.EncodeToString(append(bytes, -checksum))) + "\n")
+	}
+	upper := uint64(0)
+	for _, segment := range segments {
+		for address := segment.Address; address < segment.End(); {
+			if address>>16 != upper {
+				upper = address >> 16
+				record(0x04, 0, []byte{byte(upper >> 8), byte(upper)})
+			}
+			// records must not cross a 64 KiB boundary
+			size := min(16, segment.End()-address, (upper+1)<<16-address)
+			offset := address - segment.Address
+			record(0x00, uint16(address), segment.Data[offset:offset+size])
+			address += size
+		}
+	}
+	record(0x01, 0, nil)
+	return os.WriteFile(file, []byte(content.String()), 0644)
+}
+
+func WriteBinary(file string, segments []Segment, fill byte) error {
+	if len(segments) == 0 {
+		return os.WriteFile(file, nil, 0644)
+	}
+	start := segments[0].Address
+	end := segments[len(segments)-1].End()
+	if end-start > MaxBinaryImageSize {
+		return fmt.Errorf("binary image would span 0x%08X-0x%08X, exceeding %d bytes", start, end-1, MaxBinaryImageSize)
+	}
+	data := make([]byte, end-start)
+	for i := range data {
+		data[i] = fill
+	}
+	for _, segment := range segments {
+		copy(data[segment.Address-start:], segment.Data)
+	}
+	return os.WriteFile(file, data, 0644)
+}
+
+// ImageLayoutReport lists the address ranges of all images sorted by address
+func ImageLayoutReport(title string, images []Image) string {
+	type entry struct {
+		segment Segment
+		name    string
+	}
+	var entries []entry
+	for _, image := range images {
+		for _, segment := range image.Segments {
+			if len(segment.Data) > 0 {
+				entries = append(entries, entry{segment, image.Name})
+			}
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].segment.Address < entries[j].segment.Address })
+	content := title + "\n" + fmt.Sprintf("%-12s %-12s %10s  %s\n", "Start", "End", "Size", "Image")
+	for _, entry := range entries {
+		content += fmt.Sprintf("0x%08X   0x%08X   %10d  %s\n", entry.segment.Address, entry.segment.End()-1, len(entry.segment.Data), entry.name)
+	}
+	return content
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImages(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	t.Run("test read and write intel hex", func(t *testing.T) {
+		input := path.Join(dir, "input.hex")
+		err := os.WriteFile(input, []byte(":020000040800F2\n:0400000001020304F2\r\n:020004000506EF\n:00000001FF\n"), 0644)
+		assert.Nil(err)
+		segments, err := utils.ReadIntelHex(input)
+		assert.Nil(err)
+		assert.Equal([]utils.Segment{{Address: 0x08000000, Data: []byte{1, 2, 3, 4, 5, 6}}}, segments)
+
+		output := path.Join(dir, "output.hex")
+		assert.Nil(utils.WriteIntelHex(output, segments))
+		content, _ := os.ReadFile(output)
+		assert.Equal(":020000040800F2\n:06000000010203040506E5\n:00000001FF\n", string(content))
+	})
+
+	t.Run("test records split at 64 KiB boundary", func(t *testing.T) {
+		output := path.Join(dir, "boundary.hex")
+		assert.Nil(utils.WriteIntelHex(output, []utils.Segment{{Address: 0xFFFE, Data: []byte{1, 2, 3, 4}}}))
+		segments, err := utils.ReadIntelHex(output)
+		assert.Nil(err)
+		assert.Equal([]utils.Segment{{Address: 0xFFFE, Data: []byte{1, 2, 3, 4}}}, segments)
+	})
+
+	t.Run("test invalid intel hex", func(t *testing.T) {
+		input := path.Join(dir, "invalid.hex")
+		assert.Nil(os.WriteFile(input, []byte(":0400000001020304F3\n"), 0644))
+		_, err := utils.ReadIntelHex(input)
+		assert.ErrorContains(err, "invalid.hex:1: checksum mismatch")
+
+		assert.Nil(os.WriteFile(input, []byte(":00000004FC\n"), 0644))
+		_, err = utils.ReadIntelHex(input)
+		assert.ErrorContains(err, "invalid.hex:1: invalid byte count for record type 04")
+
+		assert.Nil(os.WriteFile(input, []byte(":020000030000FB\n"), 0644))
+		_, err = utils.ReadIntelHex(input)
+		assert.ErrorContains(err, "invalid.hex:1: invalid byte count for record type 03")
+	})
+
+	t.Run("test read binary image", func(t *testing.T) {
+		input := path.Join(dir, "input.bin")
+		assert.Nil(os.WriteFile(input, []byte{0xAA, 0xBB}, 0644))
+		_, err := utils.ReadImage(input, nil)
+		assert.ErrorContains(err, "load address is required")
+		address := uint64(0x10)
+		image, err := utils.ReadImage(input, &address)
+		assert.Nil(err)
+		assert.Equal(utils.Image{Name: "input.bin", Segments: []utils.Segment{{Address: 0x10, Data: []byte{0xAA, 0xBB}}}}, image)
+	})
+
+	t.Run("test merge images", func(t *testing.T) {
+		images := []utils.Image{
+			{Name: "app", Segments: []utils.Segment{{Address: 0x1004, Data: []byte{5, 6}}}},
+			{Name: "boot", Segments: []utils.Segment{{Address: 0x1000, Data: []byte{1, 2, 3, 4}}}},
+		}
+		segments, err := utils.MergeImages(images)
+		assert.Nil(err)
+		assert.Equal([]utils.Segment{{Address: 0x1000, Data: []byte{1, 2, 3, 4, 5, 6}}}, segments)
+
+		output := path.Join(dir, "merged.bin")
+		assert.Nil(utils.WriteBinary(output, []utils.Segment{{Address: 0, Data: []byte{1}}, {Address: 3, Data: []byte{2}}}, 0xFF))
+		content, _ := os.ReadFile(output)
+		assert.Equal([]byte{1, 0xFF, 0xFF, 2}, content)
+
+		assert.Equal("Layout\nStart        End                Size  Image\n"+
+			"0x00001000   0x00001003            4  boot\n0x00001004   0x00001005            2  app\n",
+			utils.ImageLayoutReport("Layout", images))
+	})
+
+	t.Run("test overlapping images", func(t *testing.T) {
+		images := []utils.Image{
+			{Name: "boot", Segments: []utils.Segment{{Address: 0x1000, Data: make([]byte, 0x100)}}},
+			{Name: "app", Segments: []utils.Segment{{Address: 0x1010, Data: []byte{1}}}},
+			{Name: "data", Segments: []utils.Segment{{Address: 0x1020, Data: []byte{2}}}},
+		}
+		_, err := utils.MergeImages(images)
+		assert.EqualError(err, "overlapping address ranges:\n"+
+			"  app [0x00001010-0x00001010] overlaps boot [0x00001000-0x000010FF]\n"+
+			"  data [0x00001020-0x00001020] overlaps boot [0x00001000-0x000010FF]")
+	})
+
+	t.Run("test binary image size limit", func(t *testing.T) {
+		err := utils.WriteBinary(path.Join(dir, "large.bin"), []utils.Segment{{Address: 0, Data: []byte{1}}, {Address: 0x20000000, Data: []byte{2}}}, 0xFF)
+		assert.ErrorContains(err, "exceeding")
+	})
+}
@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils
+
+import "sync"
+
+// ParallelFor calls fn for each index in [0, count) using at most jobs concurrent workers
+// and returns the error of the lowest failing index
+func ParallelFor(count int, jobs int, fn func(index int) error) error {
+	jobs = max(1, min(jobs, count))
+	errs := make([]error, count)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range jobs {
+		wg.Go(func() {
+			for index := range indexes {
+				errs[index] = fn(index)
+			}
+		})
+	}
+	for index := range count {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils
+
+import "slices"
+
+// OrderedSet is a set of strings preserving the order of insertion,
+// elements prepended one by one end up in reverse order at the front
+type OrderedSet struct {
+	front    []string
+	back     []string
+	elements map[string]struct{}
+}
+
+func NewOrderedSet(elements ...string) *OrderedSet {
+	set := &OrderedSet{elements: make(map[string]struct{}, len(elements))}
+	set.Append(elements...)
+	return set
+}
+
+func (s *OrderedSet) Contains(element string) bool {
+	_, ok := s.elements[element]
+	return ok
+}
+
+func (s *OrderedSet) add(element string) bool {
+	if s.elements == nil {
+		s.elements = make(map[string]struct{})
+	}
+	if _, ok := s.elements[element]; ok {
+		return false
+	}
+	s.elements[element] = struct{}{}
+	return true
+}
+
+// Append adds the elements not yet contained at the end
+func (s *OrderedSet) Append(elements ...string) {
+	for _, element := range elements {
+		if s.add(element) {
+			s.back = append(s.back, element)
+		}
+	}
+}
+
+// Prepend adds the elements not yet contained one by one at the front
+func (s *OrderedSet) Prepend(elements ...string) {
+	for _, element := range elements {
+		if s.add(element) {
+			s.front = append(s.front, element)
+		}
+	}
+}
+
+func (s *OrderedSet) Len() int {
+	return len(s.elements)
+}
+
+// Elements returns the elements in order, nil for an empty set
+func (s *OrderedSet) Elements() []string {
+	if s.Len() == 0 {
+		return nil
+	}
+	elements := make([]string, 0, len(s.front)+len(s.back))
+	for _, element := range slices.Backward(s.front) {
+		elements = append(elements, element)
+	}
+	return append(elements, s.back...)
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedSet(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("test append and prepend", func(t *testing.T) {
+		set := utils.NewOrderedSet("b", "c", "b")
+		set.Prepend("a", "z", "c")
+		set.Append("d", "a")
+		assert.Equal([]string{"z", "a", "b", "c", "d"}, set.Elements())
+		assert.Equal(5, set.Len())
+		assert.True(set.Contains("z"))
+		assert.False(set.Contains("y"))
+	})
+
+	t.Run("test set operations match list helpers", func(t *testing.T) {
+		var list []string
+		var set utils.OrderedSet
+		for index, element := range largeList("dir", 100) {
+			if index%3 == 0 {
+				list = utils.PrependUniquely(list, element)
+				set.Prepend(element)
+			} else {
+				list = utils.AppendUniquely(list, element)
+				set.Append(element)
+			}
+		}
+		assert.Equal(list, set.Elements())
+		assert.Nil(utils.NewOrderedSet().Elements())
+	})
+
+	t.Run("test list helpers keep duplicates semantics", func(t *testing.T) {
+		assert.Equal([]string{"c", "b", "a"}, utils.PrependUniquely([]string{"a"}, "b", "c", "b"))
+		assert.Equal([]string{"a", "b", "c"}, utils.AppendUniquely([]string{"a"}, "b", "c", "b", "a"))
+		assert.Equal([]string{"b", "a"}, utils.RemoveIncludes([]string{"a", "b", "a", "a"}, "a", "a"))
+		assert.Equal([]string{"c", "a"}, utils.Intersection([]string{"a", "b", "c"}, []string{"c", "a", "c", "d"}))
+	})
+}
+
+// largeList returns count include paths with every fourth path duplicated
+func largeList(prefix string, count int) []string {
+	list := make([]string, count)
+	for index := range list {
+		list[index] = prefix + "/include/dir" + strconv.Itoa(index-index%4/3)
+	}
+	return list
+}
+
+func BenchmarkAppendUniquely(b *testing.B) {
+	elements := largeList("${SOLUTION_ROOT}", 20000)
+	for b.Loop() {
+		var list []string
+		for _, element := range elements {
+			list = utils.AppendUniquely(list, element)
+		}
+	}
+}
+
+func BenchmarkOrderedSet(b *testing.B) {
+	elements := largeList("${SOLUTION_ROOT}", 20000)
+	for b.Loop() {
+		var set utils.OrderedSet
+		for _, element := range elements {
+			set.Append(element)
+		}
+		set.Elements()
+	}
+}
+
+func BenchmarkAppendUniquelyBulk(b *testing.B) {
+	list := largeList("${SOLUTION_ROOT}", 20000)
+	elements := largeList("${CMSIS_PACK_ROOT}", 20000)
+	for b.Loop() {
+		utils.AppendUniquely(list, elements...)
+	}
+}
+
+func BenchmarkPrependUniquely(b *testing.B) {
+	list := largeList("${SOLUTION_ROOT}", 20000)
+	elements := largeList("${CMSIS_PACK_ROOT}", 20000)
+	for b.Loop() {
+		utils.PrependUniquely(list, elements...)
+	}
+}
+
+func BenchmarkRemoveIncludes(b *testing.B) {
+	list := largeList("${SOLUTION_ROOT}", 20000)
+	remove := list[:10000]
+	for b.Loop() {
+		utils.RemoveIncludes(append([]string{}, list...), remove...)
+	}
+}
+
+func BenchmarkIntersection(b *testing.B) {
+	list := largeList("${SOLUTION_ROOT}", 20000)
+	for b.Loop() {
+		utils.Intersection(list, list)
+	}
+}
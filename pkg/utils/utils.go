@@ -7,16 +7,27 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
 )
 
 func AppendUniquely(list []string, elements ...string) []string {
+	if len(elements) == 1 {
+		if !slices.Contains(list, elements[0]) {
+			list = append(list, elements[0])
+		}
+		return list
+	}
+	contained := toSet(list)
 	for _, element := range elements {
-		if !slices.Contains(list, element) {
+		if _, ok := contained[element]; !ok {
+			contained[element] = struct{}{}
 			list = append(list, element)
 		}
 	}
@@ -24,12 +35,28 @@ func AppendUniquely(list []string, elements ...string) []string {
 }
 
 func PrependUniquely(list []string, elements ...string) []string {
+	contained := toSet(list)
+	var prepended []string
 	for _, element := range elements {
-		if !slices.Contains(list, element) {
-			list = append([]string{element}, list...)
+		if _, ok := contained[element]; !ok {
+			contained[element] = struct{}{}
+			prepended = append(prepended, element)
 		}
 	}
-	return list
+	if len(prepended) == 0 {
+		return list
+	}
+	// elements are prepended one by one, the last one ends up first
+	slices.Reverse(prepended)
+	return append(prepended, list...)
+}
+
+func toSet(list []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(list))
+	for _, element := range list {
+		set[element] = struct{}{}
+	}
+	return set
 }
 
 func FindLast(list []string, substr string) string {
@@ -43,63 +70,147 @@ func FindLast(list []string, substr string) string {
 
 func Intersection(slice1, slice2 []string) []string {
 	var intersection []string
-	hash := make(map[string]bool)
-	for _, element := range slice1 {
-		hash[element] = true
-	}
+	hash := toSet(slice1)
 	for _, element := range slice2 {
-		if hash[element] {
-			intersection = AppendUniquely(intersection, element)
+		if _, ok := hash[element]; ok {
+			delete(hash, element)
+			intersection = append(intersection, element)
 		}
 	}
 	return intersection
 }
 
+// EditDistance returns the Levenshtein distance between two strings
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// NearestMatch returns the candidate closest to name, or an empty string if none is similar enough
+func NearestMatch(name string, candidates []string) string {
+	var nearest string
+	threshold := max(2, len(name)/3)
+	for _, candidate := range candidates {
+		if distance := EditDistance(strings.ToLower(name), strings.ToLower(candidate)); distance <= threshold {
+			nearest, threshold = candidate, distance-1
+		}
+	}
+	return nearest
+}
+
 func RemoveIncludes(includes []string, delpaths ...string) []string {
+	if len(delpaths) == 0 {
+		return includes
+	}
+	// each delpath removes the first remaining occurrence
+	count := make(map[string]int, len(delpaths))
 	for _, delpath := range delpaths {
-		index := slices.Index(includes, delpath)
-		if index > -1 {
-			includes = append(includes[:index], includes[index+1:]...)
+		count[delpath]++
+	}
+	var remaining []string
+	for _, include := range includes {
+		if count[include] > 0 {
+			count[include]--
+			continue
 		}
+		remaining = append(remaining, include)
 	}
-	return includes
+	return remaining
 }
 
 func AppendDefines(defines []interface{}, elements []interface{}) []interface{} {
-	return append(defines, elements...)
+	return slices.Concat(defines, elements)
 }
 
+// RemoveDefines removes all definitions of the given macro names
 func RemoveDefines(defines []interface{}, undefines ...string) []interface{} {
-	for _, undefine := range undefines {
-		for index, define := range defines {
-			key, _ := GetDefine(define)
-			if key == undefine {
-				defines = append(defines[:index], defines[index+1:]...)
-				break
-			}
+	var remaining []interface{}
+	for _, define := range defines {
+		key, _ := GetDefine(define)
+		if !slices.Contains(undefines, key) {
+			remaining = append(remaining, define)
 		}
 	}
-	return defines
+	return remaining
 }
 
-func GetDefine(define interface{}) (key string, value string) {
+// Define is a preprocessor macro, the value is only meaningful when HasValue is set
+type Define struct {
+	Name     string
+	Value    string
+	HasValue bool
+}
+
+// String returns the macro in the NAME or NAME=VALUE form
+func (d Define) String() string {
+	if d.HasValue {
+		return d.Name + "=" + d.Value
+	}
+	return d.Name
+}
+
+var macroNamePattern = regexp.MustCompile(`^[A-Za-z_]\w*(\([\w\s,.]*\))?$`)
+
+// ParseDefine returns the macro of a define entry, either a plain name or a map with
+// a single name and its scalar value, a null value defines the macro without value
+func ParseDefine(define interface{}) (Define, error) {
+	var result Define
 	switch def := define.(type) {
 	case string:
-		key = def
+		result.Name = def
 	case map[string]interface{}:
-		for k, v := range def {
-			key = k
-			switch val := v.(type) {
+		if len(def) != 1 {
+			return result, errors.New("define must have exactly one macro: " + fmt.Sprint(def))
+		}
+		for key, value := range def {
+			result.Name = key
+			result.HasValue = value != nil
+			switch val := value.(type) {
+			case nil:
 			case string:
-				value = val
+				result.Value = val
 			case bool:
-				value = strconv.FormatBool(val)
+				result.Value = strconv.FormatBool(val)
 			case int:
-				value = strconv.Itoa(val)
+				result.Value = strconv.Itoa(val)
+			case int64:
+				result.Value = strconv.FormatInt(val, 10)
+			case uint64:
+				result.Value = strconv.FormatUint(val, 10)
+			case float64:
+				result.Value = strconv.FormatFloat(val, 'g', -1, 64)
+			default:
+				return result, errors.New("invalid value of macro '" + key + "': " + fmt.Sprint(val))
 			}
 		}
+	default:
+		return result, errors.New("invalid define: " + fmt.Sprint(define))
 	}
-	return key, value
+	if !macroNamePattern.MatchString(result.Name) {
+		return result, errors.New("invalid macro name '" + result.Name + "'")
+	}
+	return result, nil
+}
+
+func GetDefine(define interface{}) (key string, value string) {
+	def, _ := ParseDefine(define)
+	return def.Name, def.Value
 }
 
 func ReadFileContent(filename string) (string, error) {
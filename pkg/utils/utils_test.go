@@ -7,7 +7,9 @@
 package utils_test
 
 import (
+	"errors"
 	"os"
+	"strconv"
 	"testing"
 
 	"github.com/Open-CMSIS-Pack/cbuild2cmake/pkg/utils"
@@ -51,6 +53,8 @@ func TestUtils(t *testing.T) {
 	t.Run("test RemoveDefines", func(t *testing.T) {
 		assert.Equal([]interface{}{"one", "three"}, utils.RemoveDefines([]interface{}{"one", "two", "three"}, "two"))
 		assert.Equal([]interface{}{"one", "two", "three"}, utils.RemoveDefines([]interface{}{"one", "two", "three"}, "four"))
+		assert.Equal([]interface{}{"one"}, utils.RemoveDefines([]interface{}{"two", "one", map[string]interface{}{"two": 2}}, "two"))
+		assert.Equal([]interface{}{"TWO_LEVEL"}, utils.RemoveDefines([]interface{}{"TWO_LEVEL", "TWO"}, "TWO"))
 	})
 
 	t.Run("test GetDefine", func(t *testing.T) {
@@ -70,6 +74,44 @@ func TestUtils(t *testing.T) {
 		assert.Equal("key", key)
 	})
 
+	t.Run("test ParseDefine", func(t *testing.T) {
+		define, err := utils.ParseDefine("DEBUG")
+		assert.Nil(err)
+		assert.Equal(utils.Define{Name: "DEBUG"}, define)
+		assert.Equal("DEBUG", define.String())
+
+		define, err = utils.ParseDefine(map[string]interface{}{"EMPTY": ""})
+		assert.Nil(err)
+		assert.Equal("EMPTY=", define.String())
+
+		define, err = utils.ParseDefine(map[string]interface{}{"NONE": nil})
+		assert.Nil(err)
+		assert.Equal("NONE", define.String())
+
+		define, err = utils.ParseDefine(map[string]interface{}{"RATIO": 1.5})
+		assert.Nil(err)
+		assert.Equal("RATIO=1.5", define.String())
+
+		define, err = utils.ParseDefine(map[string]interface{}{"MAX(a,b)": "((a)>(b)?(a):(b))"})
+		assert.Nil(err)
+		assert.Equal("MAX(a,b)=((a)>(b)?(a):(b))", define.String())
+
+		_, err = utils.ParseDefine("1DEBUG")
+		assert.EqualError(err, "invalid macro name '1DEBUG'")
+
+		_, err = utils.ParseDefine(map[string]interface{}{"DEF-X": 1})
+		assert.EqualError(err, "invalid macro name 'DEF-X'")
+
+		_, err = utils.ParseDefine(map[string]interface{}{"LIST": []interface{}{1, 2}})
+		assert.EqualError(err, "invalid value of macro 'LIST': [1 2]")
+
+		_, err = utils.ParseDefine(map[string]interface{}{"A": 1, "B": 2})
+		assert.EqualError(err, "define must have exactly one macro: map[A:1 B:2]")
+
+		_, err = utils.ParseDefine(42)
+		assert.EqualError(err, "invalid define: 42")
+	})
+
 	t.Run("test file operations", func(t *testing.T) {
 		// write file and read content back
 		filename := testRoot + "/test.txt"
@@ -106,4 +148,28 @@ func TestUtils(t *testing.T) {
 		assert.Equal("CMSIS", name)
 		assert.Equal("6.1.0", version)
 	})
+
+	t.Run("test EditDistance and NearestMatch", func(t *testing.T) {
+		assert.Equal(0, utils.EditDistance("Build", "Build"))
+		assert.Equal(3, utils.EditDistance("kitten", "sitting"))
+		assert.Equal(4, utils.EditDistance("", "test"))
+		candidates := []string{"Generate_Project_Sources", "Archive_Artifacts"}
+		assert.Equal("Generate_Project_Sources", utils.NearestMatch("Generate_Project_Source", candidates))
+		assert.Equal("Archive_Artifacts", utils.NearestMatch("archive_artifacts", candidates))
+		assert.Equal("", utils.NearestMatch("Unrelated", candidates))
+	})
+
+	t.Run("test ParallelFor", func(t *testing.T) {
+		results := make([]int, 100)
+		err := utils.ParallelFor(len(results), 4, func(index int) error {
+			results[index] = index * index
+			if index == 70 || index == 30 {
+				return errors.New("failed " + strconv.Itoa(index))
+			}
+			return nil
+		})
+		assert.EqualError(err, "failed 30")
+		assert.Equal(99*99, results[99])
+		assert.Nil(utils.ParallelFor(0, 4, func(index int) error { return nil }))
+	})
 }
@@ -31,9 +31,9 @@ target_compile_options(Group_AC6 PUBLIC
   $<TARGET_PROPERTY:${CONTEXT},INTERFACE_COMPILE_OPTIONS>
 )
 set(COMPILE_DEFINITIONS
-  HEXADECIMAL_TEST=11259375
+  HEXADECIMAL_TEST=0xABCDEF
   DECIMAL_TEST=1234567890
-  STRING_TEST="String0"
+  "STRING_TEST=\"String0\""
   GROUP_ASM_AC6_DEF
 )
 cbuild_set_defines(AS_ARM COMPILE_DEFINITIONS)
@@ -0,0 +1 @@
+# components.cmake
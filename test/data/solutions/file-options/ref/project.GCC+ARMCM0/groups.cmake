@@ -0,0 +1,83 @@
+# groups.cmake
+
+# group Source
+add_library(Group_Source OBJECT
+  "${SOLUTION_ROOT}/project/main.c"
+)
+target_include_directories(Group_Source PUBLIC
+  $<TARGET_PROPERTY:${CONTEXT},INTERFACE_INCLUDE_DIRECTORIES>
+)
+target_compile_definitions(Group_Source PUBLIC
+  $<TARGET_PROPERTY:${CONTEXT},INTERFACE_COMPILE_DEFINITIONS>
+)
+target_compile_options(Group_Source PUBLIC
+  $<TARGET_PROPERTY:${CONTEXT},INTERFACE_COMPILE_OPTIONS>
+)
+
+# files driver1.c driver2.c driver3.c
+add_library(Group_Source_driver1_c OBJECT
+  "${SOLUTION_ROOT}/project/driver1.c"
+  "${SOLUTION_ROOT}/project/driver2.c"
+  "${SOLUTION_ROOT}/project/driver3.c"
+)
+target_include_directories(Group_Source_driver1_c PUBLIC
+  $<TARGET_PROPERTY:Group_Source,INTERFACE_INCLUDE_DIRECTORIES>
+  $<$<COMPILE_LANGUAGE:C,CXX>:
+    "${SOLUTION_ROOT}/project/drivers"
+  >
+)
+target_compile_definitions(Group_Source_driver1_c PUBLIC
+  $<$<COMPILE_LANGUAGE:C,CXX>:
+    DRIVER=1
+  >
+  $<TARGET_PROPERTY:Group_Source,INTERFACE_COMPILE_DEFINITIONS>
+)
+target_compile_options(Group_Source_driver1_c PUBLIC
+  $<TARGET_PROPERTY:Group_Source,INTERFACE_COMPILE_OPTIONS>
+)
+
+# file test.c
+add_library(Group_Source_test_c OBJECT
+  "${SOLUTION_ROOT}/project/test.c"
+)
+target_include_directories(Group_Source_test_c PUBLIC
+  $<TARGET_PROPERTY:Group_Source,INTERFACE_INCLUDE_DIRECTORIES>
+)
+target_compile_definitions(Group_Source_test_c PUBLIC
+  $<$<COMPILE_LANGUAGE:C,CXX>:
+    TEST
+  >
+  $<TARGET_PROPERTY:Group_Source,INTERFACE_COMPILE_DEFINITIONS>
+)
+target_compile_options(Group_Source_test_c PUBLIC
+  $<TARGET_PROPERTY:Group_Source,INTERFACE_COMPILE_OPTIONS>
+)
+
+# files startup1.s startup2.s
+add_library(Group_Source_startup1_s OBJECT
+  "${SOLUTION_ROOT}/project/startup1.s"
+  "${SOLUTION_ROOT}/project/startup2.s"
+)
+target_include_directories(Group_Source_startup1_s PUBLIC
+  $<TARGET_PROPERTY:Group_Source,INTERFACE_INCLUDE_DIRECTORIES>
+  $<$<COMPILE_LANGUAGE:ASM>:
+    "${SOLUTION_ROOT}/project/asm"
+  >
+)
+target_compile_options(Group_Source_startup1_s PUBLIC
+  $<TARGET_PROPERTY:Group_Source,INTERFACE_COMPILE_OPTIONS>
+)
+set(COMPILE_DEFINITIONS
+  ARMCM0
+)
+cbuild_set_defines(AS_GNU COMPILE_DEFINITIONS)
+set_source_files_properties("${SOLUTION_ROOT}/project/startup1.s" PROPERTIES
+  COMPILE_FLAGS "${COMPILE_DEFINITIONS}"
+)
+set(COMPILE_DEFINITIONS
+  ARMCM0
+)
+cbuild_set_defines(AS_GNU COMPILE_DEFINITIONS)
+set_source_files_properties("${SOLUTION_ROOT}/project/startup2.s" PROPERTIES
+  COMPILE_FLAGS "${COMPILE_DEFINITIONS}"
+)
@@ -7,7 +7,7 @@ target_include_directories(ARM_CMSIS_CORE_6_0_0 INTERFACE
   "${CMSIS_PACK_ROOT}/ARM/CMSIS/6.0.0/CMSIS/Core/Include"
 )
 target_compile_definitions(ARM_CMSIS_CORE_6_0_0 INTERFACE
-  $<LIST:FILTER,$<TARGET_PROPERTY:${CONTEXT},INTERFACE_COMPILE_DEFINITIONS>,EXCLUDE,^DEF1.*>
+  $<LIST:FILTER,$<TARGET_PROPERTY:${CONTEXT},INTERFACE_COMPILE_DEFINITIONS>,EXCLUDE,^DEF1$|^DEF1=>
 )
 
 # component ARM::Device:Startup&C Startup@2.2.0
@@ -59,7 +59,7 @@ target_compile_definitions(Group_Source1_source3_c PUBLIC
   $<$<COMPILE_LANGUAGE:C,CXX>:
     DEF3
   >
-  $<LIST:FILTER,$<TARGET_PROPERTY:Group_Source1,INTERFACE_COMPILE_DEFINITIONS>,EXCLUDE,^DEF1.*>
+  $<LIST:FILTER,$<TARGET_PROPERTY:Group_Source1,INTERFACE_COMPILE_DEFINITIONS>,EXCLUDE,^DEF1$|^DEF1=>
 )
 target_compile_options(Group_Source1_source3_c PUBLIC
   $<TARGET_PROPERTY:Group_Source1,INTERFACE_COMPILE_OPTIONS>
@@ -79,7 +79,7 @@ target_compile_definitions(Group_Source1_Source2 PUBLIC
   $<$<COMPILE_LANGUAGE:C,CXX>:
     DEF2=1
   >
-  $<LIST:FILTER,$<TARGET_PROPERTY:Group_Source1,INTERFACE_COMPILE_DEFINITIONS>,EXCLUDE,^DEF1.*>
+  $<LIST:FILTER,$<TARGET_PROPERTY:Group_Source1,INTERFACE_COMPILE_DEFINITIONS>,EXCLUDE,^DEF1$|^DEF1=>
 )
 target_compile_options(Group_Source1_Source2 PUBLIC
   $<TARGET_PROPERTY:Group_Source1,INTERFACE_COMPILE_OPTIONS>
@@ -0,0 +1,10 @@
+/*
+ * Auto generated Run-Time-Environment Configuration File
+ */
+
+#ifndef RTE_COMPONENTS_H
+#define RTE_COMPONENTS_H
+
+#define CMSIS_device_header "ARMCM0.h"
+
+#endif /* RTE_COMPONENTS_H */
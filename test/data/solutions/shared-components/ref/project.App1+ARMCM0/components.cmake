@@ -0,0 +1,29 @@
+# components.cmake
+
+# component ARM::CMSIS:CORE@6.0.0
+add_library(ARM_CMSIS_CORE_6_0_0 INTERFACE)
+target_include_directories(ARM_CMSIS_CORE_6_0_0 INTERFACE
+  $<TARGET_PROPERTY:${CONTEXT},INTERFACE_INCLUDE_DIRECTORIES>
+  "${CMSIS_PACK_ROOT}/ARM/CMSIS/6.0.0/CMSIS/Core/Include"
+)
+target_compile_definitions(ARM_CMSIS_CORE_6_0_0 INTERFACE
+  $<TARGET_PROPERTY:${CONTEXT},INTERFACE_COMPILE_DEFINITIONS>
+)
+
+# component ARM::Device:Startup&C Startup@2.2.0
+add_library(ARM_Device_Startup_C_Startup_2_2_0 OBJECT
+  "${SOLUTION_ROOT}/project/RTE/Device/ARMCM0/startup_ARMCM0.c"
+  "${SOLUTION_ROOT}/project/RTE/Device/ARMCM0/system_ARMCM0.c"
+)
+add_library(ARM_Device_Startup_C_Startup_2_2_0_SHARED STATIC $<TARGET_OBJECTS:ARM_Device_Startup_C_Startup_2_2_0>)
+set_target_properties(ARM_Device_Startup_C_Startup_2_2_0_SHARED PROPERTIES PREFIX "" SUFFIX ".a" OUTPUT_NAME "ARM_Device_Startup_C_Startup_2_2_0"
+  ARCHIVE_OUTPUT_DIRECTORY "${SHARED_COMPONENTS_DIR}/ec729948034e03ba")
+target_include_directories(ARM_Device_Startup_C_Startup_2_2_0 PUBLIC
+  $<TARGET_PROPERTY:${CONTEXT},INTERFACE_INCLUDE_DIRECTORIES>
+)
+target_compile_definitions(ARM_Device_Startup_C_Startup_2_2_0 PUBLIC
+  $<TARGET_PROPERTY:${CONTEXT},INTERFACE_COMPILE_DEFINITIONS>
+)
+target_compile_options(ARM_Device_Startup_C_Startup_2_2_0 PUBLIC
+  $<TARGET_PROPERTY:${CONTEXT},INTERFACE_COMPILE_OPTIONS>
+)
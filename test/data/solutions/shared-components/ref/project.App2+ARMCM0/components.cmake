@@ -0,0 +1,28 @@
+# components.cmake
+
+# component ARM::CMSIS:CORE@6.0.0
+add_library(ARM_CMSIS_CORE_6_0_0 INTERFACE)
+target_include_directories(ARM_CMSIS_CORE_6_0_0 INTERFACE
+  $<TARGET_PROPERTY:${CONTEXT},INTERFACE_INCLUDE_DIRECTORIES>
+  "${CMSIS_PACK_ROOT}/ARM/CMSIS/6.0.0/CMSIS/Core/Include"
+)
+target_compile_definitions(ARM_CMSIS_CORE_6_0_0 INTERFACE
+  $<TARGET_PROPERTY:${CONTEXT},INTERFACE_COMPILE_DEFINITIONS>
+)
+
+# component ARM::Device:Startup&C Startup@2.2.0 (shared, built by project.App1+ARMCM0)
+add_library(ARM_Device_Startup_C_Startup_2_2_0 INTERFACE)
+target_include_directories(ARM_Device_Startup_C_Startup_2_2_0 INTERFACE
+  $<TARGET_PROPERTY:${CONTEXT},INTERFACE_INCLUDE_DIRECTORIES>
+)
+target_compile_definitions(ARM_Device_Startup_C_Startup_2_2_0 INTERFACE
+  $<TARGET_PROPERTY:${CONTEXT},INTERFACE_COMPILE_DEFINITIONS>
+)
+target_compile_options(ARM_Device_Startup_C_Startup_2_2_0 INTERFACE
+  $<TARGET_PROPERTY:${CONTEXT},INTERFACE_COMPILE_OPTIONS>
+)
+target_link_libraries(ARM_Device_Startup_C_Startup_2_2_0 INTERFACE
+  -Wl,--whole-archive
+    ${SHARED_COMPONENTS_DIR}/ec729948034e03ba/ARM_Device_Startup_C_Startup_2_2_0.a
+  -Wl,--no-whole-archive
+)
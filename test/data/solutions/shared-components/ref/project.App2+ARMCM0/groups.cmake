@@ -0,0 +1,15 @@
+# groups.cmake
+
+# group Source
+add_library(Group_Source OBJECT
+  "${SOLUTION_ROOT}/project/app2.c"
+)
+target_include_directories(Group_Source PUBLIC
+  $<TARGET_PROPERTY:${CONTEXT},INTERFACE_INCLUDE_DIRECTORIES>
+)
+target_compile_definitions(Group_Source PUBLIC
+  $<TARGET_PROPERTY:${CONTEXT},INTERFACE_COMPILE_DEFINITIONS>
+)
+target_compile_options(Group_Source PUBLIC
+  $<TARGET_PROPERTY:${CONTEXT},INTERFACE_COMPILE_OPTIONS>
+)